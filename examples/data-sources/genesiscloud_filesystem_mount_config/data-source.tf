@@ -0,0 +1,41 @@
+resource "genesiscloud_filesystem" "datasets" {
+  name   = "datasets"
+  region = "NORD-NO-KRS-1"
+  size   = 500
+  type   = "vast"
+}
+
+resource "genesiscloud_filesystem" "checkpoints" {
+  name   = "checkpoints"
+  region = "NORD-NO-KRS-1"
+  size   = 100
+  type   = "vast"
+}
+
+data "genesiscloud_filesystem_mount_config" "training" {
+  filesystems = [
+    {
+      id = genesiscloud_filesystem.datasets.id
+    },
+    {
+      id          = genesiscloud_filesystem.checkpoints.id
+      mount_point = "/checkpoints"
+    },
+  ]
+}
+
+resource "genesiscloud_instance" "training" {
+  name   = "training"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu:22.04"
+  type  = "vcpu-2_memory-4g"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+
+  metadata = {
+    startup_script = data.genesiscloud_filesystem_mount_config.training.cloud_init
+  }
+}
@@ -0,0 +1,21 @@
+data "genesiscloud_snapshot" "latest" {
+  filter = {
+    name_regex         = "^nightly-"
+    source_instance_id = genesiscloud_instance.example.id
+    status             = "created"
+  }
+
+  most_recent = true
+}
+
+resource "genesiscloud_instance" "restored" {
+  name   = "restored"
+  region = data.genesiscloud_snapshot.latest.region
+
+  image = data.genesiscloud_snapshot.latest.id
+  type  = "vcpu-2_memory-4g"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+}
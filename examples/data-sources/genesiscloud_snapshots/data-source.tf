@@ -0,0 +1,14 @@
+data "genesiscloud_snapshots" "nightly" {
+  filter = {
+    name_regex         = "^nightly-"
+    source_instance_id = genesiscloud_instance.example.id
+    status             = "created"
+  }
+}
+
+data "genesiscloud_snapshots" "recent" {
+  filter = {
+    region        = "NORD-NO-KRS-1"
+    created_after = "2024-01-01T00:00:00Z"
+  }
+}
@@ -0,0 +1,10 @@
+ephemeral "genesiscloud_ssh_key_pair" "example" {
+  algorithm = "rsa"
+  rsa_bits  = 4096
+}
+
+resource "genesiscloud_ssh_key" "example" {
+  name                  = "example"
+  public_key_wo         = ephemeral.genesiscloud_ssh_key_pair.example.public_key_openssh
+  public_key_wo_version = 1
+}
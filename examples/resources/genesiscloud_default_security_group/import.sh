@@ -0,0 +1 @@
+terraform import genesiscloud_default_security_group.default 18efeec8-94f0-4776-8ff2-5e9b49c74608
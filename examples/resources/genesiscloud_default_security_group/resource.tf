@@ -0,0 +1,32 @@
+resource "genesiscloud_default_security_group" "default" {
+  region = "NORD-NO-KRS-1"
+
+  # only allow SSH from a trusted network
+  rules = [
+    {
+      direction      = "ingress"
+      protocol       = "tcp"
+      port_range_min = 22
+      port_range_max = 22
+      remote_cidr    = "203.0.113.0/24"
+    },
+    {
+      direction = "egress"
+      protocol  = "all"
+    }
+  ]
+
+  # restore unrestricted SSH on destroy
+  baseline_rules = [
+    {
+      direction      = "ingress"
+      protocol       = "tcp"
+      port_range_min = 22
+      port_range_max = 22
+    },
+    {
+      direction = "egress"
+      protocol  = "all"
+    }
+  ]
+}
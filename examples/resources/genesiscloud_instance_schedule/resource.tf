@@ -0,0 +1,21 @@
+resource "genesiscloud_instance" "example" {
+  name   = "example"
+  region = "NORD-NO-KRS-1"
+
+  image = "ubuntu:22.04"
+  type  = "vcpu-2_memory-4g"
+
+  ssh_key_ids = [
+    "my-ssh-key-id"
+  ]
+}
+
+# keep the instance running during office hours only,
+# enforced by running `terraform apply` periodically
+resource "genesiscloud_instance_schedule" "example" {
+  instance_id = genesiscloud_instance.example.id
+
+  start_schedule = "0 8 * * MON-FRI"
+  stop_schedule  = "0 20 * * MON-FRI"
+  timezone       = "Europe/Berlin"
+}
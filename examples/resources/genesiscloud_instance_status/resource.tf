@@ -14,4 +14,9 @@ resource "genesiscloud_instance" "example" {
 resource "genesiscloud_instance_status" "example" {
   instance_id = genesiscloud_instance.example.id
   status      = "active"
+
+  # reboot the instance whenever the configuration changes
+  triggers = {
+    config = sha256(file("${path.module}/app.conf"))
+  }
 }
@@ -7,6 +7,13 @@ resource "genesiscloud_security_group" "allow-https" {
       protocol       = "tcp"
       port_range_min = 443
       port_range_max = 443
+    },
+    {
+      direction      = "ingress"
+      protocol       = "tcp"
+      port_range_min = 22
+      port_range_max = 22
+      remote_cidr    = "203.0.113.0/24"
     }
   ]
 }
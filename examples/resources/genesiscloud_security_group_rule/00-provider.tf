@@ -0,0 +1,11 @@
+terraform {
+  required_providers {
+    genesiscloud = {
+      source = "genesiscloud/genesiscloud"
+    }
+  }
+}
+
+provider "genesiscloud" {
+  # optional configuration...
+}
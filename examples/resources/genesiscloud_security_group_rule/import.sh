@@ -0,0 +1,2 @@
+terraform import genesiscloud_security_group_rule.example 18efeec8-94f0-4776-8ff2-5e9b49c74608/ingress/tcp/443-443
+terraform import genesiscloud_security_group_rule.example 18efeec8-94f0-4776-8ff2-5e9b49c74608/ingress/tcp/22-22@203.0.113.0/24
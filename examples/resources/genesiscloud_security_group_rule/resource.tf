@@ -0,0 +1,24 @@
+resource "genesiscloud_security_group" "shared" {
+  name   = "shared"
+  region = "NORD-NO-KRS-1"
+}
+
+resource "genesiscloud_security_group_rule" "allow-https" {
+  security_group_id = genesiscloud_security_group.shared.id
+  direction         = "ingress"
+  protocol          = "tcp"
+  port_range_min    = 443
+  port_range_max    = 443
+}
+
+resource "genesiscloud_security_group" "workers" {
+  name   = "workers"
+  region = "NORD-NO-KRS-1"
+}
+
+resource "genesiscloud_security_group_rule" "allow-workers" {
+  security_group_id        = genesiscloud_security_group.shared.id
+  direction                = "ingress"
+  protocol                 = "all"
+  remote_security_group_id = genesiscloud_security_group.workers.id
+}
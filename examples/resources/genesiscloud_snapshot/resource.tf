@@ -8,3 +8,18 @@ resource "genesiscloud_snapshot" "example" {
 
   retain_on_delete = true # optional
 }
+
+resource "genesiscloud_snapshot" "replicated" {
+  name               = "replicated"
+  source_instance_id = genesiscloud_instance.target.id
+
+  replicated_region = "EUC-DE-MUC-1"
+  wait_for_replicas = true
+}
+
+resource "genesiscloud_instance" "other_region" {
+  # ...
+
+  region = "EUC-DE-MUC-1"
+  image  = genesiscloud_snapshot.replicated.replicas[0].id
+}
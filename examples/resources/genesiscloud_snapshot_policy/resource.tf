@@ -0,0 +1,13 @@
+resource "genesiscloud_instance" "example" {
+  # ...
+}
+
+# Run `terraform apply` at least daily, e.g. from a scheduled CI pipeline
+resource "genesiscloud_snapshot_policy" "nightly" {
+  source_instance_id = genesiscloud_instance.example.id
+  name_prefix        = "nightly-"
+
+  interval    = "24h"
+  keep_last   = 7
+  keep_within = "168h"
+}
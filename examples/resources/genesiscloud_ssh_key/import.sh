@@ -1 +1,5 @@
 terraform import genesiscloud_ssh_key.example 18efeec8-94f0-4776-8ff2-5e9b49c74608
+
+# Import by the fingerprint or the name of the SSH key
+terraform import genesiscloud_ssh_key.example fingerprint:SHA256:2cb3Jx5lvRjLM2mFpOGO/A1T64fBD0DhfhKfOPfEsjI
+terraform import genesiscloud_ssh_key.example name:example
@@ -2,3 +2,23 @@ resource "genesiscloud_ssh_key" "example" {
   name       = "example"
   public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBOpdKM8wSI07+PO4xLDL7zW/kNWGbdFXeHyBU1TRlBn alice@example.com"
 }
+
+# Generate the key pair, the private key is stored in the state
+resource "genesiscloud_ssh_key" "generated" {
+  name = "generated"
+
+  generate = {
+    algorithm = "ed25519"
+  }
+}
+
+# Generate the key pair without storing the private key in the state (Terraform 1.11 or later)
+ephemeral "genesiscloud_ssh_key_pair" "ci" {
+  algorithm = "ed25519"
+}
+
+resource "genesiscloud_ssh_key" "ci" {
+  name                  = "ci"
+  public_key_wo         = ephemeral.genesiscloud_ssh_key_pair.ci.public_key_openssh
+  public_key_wo_version = 1
+}
@@ -0,0 +1 @@
+terraform import genesiscloud_ssh_key_set.example team-
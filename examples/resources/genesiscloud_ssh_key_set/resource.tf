@@ -0,0 +1,18 @@
+resource "genesiscloud_ssh_key_set" "team" {
+  name_prefix     = "team-"
+  authorized_keys = file("${path.module}/authorized_keys")
+}
+
+resource "genesiscloud_ssh_key_set" "ci" {
+  name_prefix = "ci-"
+
+  keys = {
+    "deploy" = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBOpdKM8wSI07+PO4xLDL7zW/kNWGbdFXeHyBU1TRlBn deploy@example.com"
+  }
+}
+
+resource "genesiscloud_instance" "example" {
+  # ...
+
+  ssh_key_ids = values(genesiscloud_ssh_key_set.team.ssh_key_ids)
+}
@@ -4,3 +4,21 @@ resource "genesiscloud_volume" "example" {
   size   = 50
   type   = "hdd"
 }
+
+resource "genesiscloud_volume" "restored" {
+  name   = "restored"
+  region = "NORD-NO-KRS-1"
+  size   = 100 # at least the size of the snapshot
+  type   = "hdd"
+
+  source_snapshot_id = "my-snapshot-id"
+}
+
+resource "genesiscloud_volume" "experiment" {
+  name   = "experiment"
+  region = genesiscloud_volume.example.region
+  size   = genesiscloud_volume.example.size
+  type   = genesiscloud_volume.example.type
+
+  source_volume_id = genesiscloud_volume.example.id
+}
@@ -0,0 +1,65 @@
+package cidrvalidator
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = cidrNetworkValidator{}
+
+// cidrNetworkValidator validates that a string Attribute's value is an IPv4 or IPv6 network in CIDR notation.
+type cidrNetworkValidator struct {
+}
+
+// Description describes the validation in plain text formatting.
+func (validator cidrNetworkValidator) Description(_ context.Context) string {
+	return `string must be an IPv4 or IPv6 network in CIDR notation without host bits, for example "10.0.0.0/24" or "2001:db8::/32"`
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (validator cidrNetworkValidator) MarkdownDescription(ctx context.Context) string {
+	return validator.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (validator cidrNetworkValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	s := req.ConfigValue
+
+	if s.IsUnknown() || s.IsNull() {
+		return
+	}
+
+	prefix, err := netip.ParsePrefix(s.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid Attribute Value CIDR",
+			fmt.Sprintf("%q %s", s.ValueString(), validator.Description(ctx))),
+		)
+		return
+	}
+
+	if masked := prefix.Masked(); masked != prefix {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"CIDR Must Not Have Host Bits Set",
+			fmt.Sprintf("%q %s. Did you mean %q?", s.ValueString(), validator.Description(ctx), masked.String())),
+		)
+		return
+	}
+}
+
+// Network returns an AttributeValidator which ensures that any configured
+// attribute value:
+//
+//   - Is parseable as IPv4 or IPv6 CIDR.
+//   - Has no host bits set, so the value is stable when read back.
+//
+// Null (unconfigured) and unknown (known after apply) values are skipped.
+func Network() validator.String {
+	return cidrNetworkValidator{}
+}
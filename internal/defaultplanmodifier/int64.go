@@ -0,0 +1,46 @@
+package defaultplanmodifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ planmodifier.Int64 = (*defaultInt64ValueAttributePlanModifier)(nil)
+
+// defaultInt64ValueAttributePlanModifier specifies a default value (types.Int64) for an attribute.
+type defaultInt64ValueAttributePlanModifier struct {
+	DefaultValue types.Int64
+}
+
+// Int64 is a helper to instantiate a defaultValueAttributePlanModifier.
+func Int64(v int64) planmodifier.Int64 {
+	return &defaultInt64ValueAttributePlanModifier{
+		DefaultValue: types.Int64Value(v),
+	}
+}
+
+func (apm *defaultInt64ValueAttributePlanModifier) Description(ctx context.Context) string {
+	return apm.MarkdownDescription(ctx)
+}
+
+func (apm *defaultInt64ValueAttributePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Sets the default value %q if the attribute is not set.", apm.DefaultValue)
+}
+
+func (apm *defaultInt64ValueAttributePlanModifier) PlanModifyInt64(_ context.Context, req planmodifier.Int64Request, res *planmodifier.Int64Response) {
+	// If the attribute configuration is not null, we are done here
+	if !req.ConfigValue.IsNull() {
+		return
+	}
+
+	// If the attribute plan is "known" and "not null", then a previous plan modifier in the sequence
+	// has already been applied, and we don't want to interfere.
+	if !req.PlanValue.IsUnknown() && !req.PlanValue.IsNull() {
+		return
+	}
+
+	res.PlanValue = apm.DefaultValue
+}
@@ -30,6 +30,12 @@ type Client struct {
 	*genesiscloud.ClientWithResponses
 
 	PollingInterval time.Duration
+
+	// SecurityGroupLocks serializes rule updates per security group.
+	SecurityGroupLocks KeyedMutex
+
+	// SecurityGroupRules tracks whether security group rules are managed inline or standalone.
+	SecurityGroupRules SecurityGroupRulesRegistry
 }
 
 func (c *Client) PollingWait(ctx context.Context) error {
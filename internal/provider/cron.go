@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// cronSearchLimit bounds the search for the next or previous occurrence, so
+// expressions which never match, e.g. `0 0 30 2 *`, terminate.
+const cronSearchLimit = 5 // years
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// cronSchedule is a parsed cron expression with the five standard fields
+// `minute hour day-of-month month day-of-week`.
+type cronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// Like in cron, a day matches either field if both are restricted.
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+// parseCronSchedule parses a cron expression. Each field supports `*`, values,
+// ranges `a-b`, steps `*/n` or `a-b/n` and lists separated by commas. Months
+// and days of the week can also be given by their three letter names.
+func parseCronSchedule(expression string) (*cronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+
+		bits[i], err = parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Sunday is both 0 and 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = (bits[4] | 1) &^ (1 << 7)
+	}
+
+	return &cronSchedule{
+		minute:               bits[0],
+		hour:                 bits[1],
+		dayOfMonth:           bits[2],
+		month:                bits[3],
+		dayOfWeek:            bits[4],
+		dayOfMonthRestricted: !strings.HasPrefix(fields[2], "*"),
+		dayOfWeekRestricted:  !strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (bits uint64, err error) {
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.Split(part, "/")
+		if len(rangeAndStep) > 2 {
+			return 0, fmt.Errorf("invalid %s %q", bounds.name, part)
+		}
+
+		var low, high int
+
+		switch lowAndHigh := strings.Split(rangeAndStep[0], "-"); {
+		case rangeAndStep[0] == "*":
+			low, high = bounds.min, bounds.max
+		case len(lowAndHigh) == 2:
+			low, err = parseCronValue(lowAndHigh[0], bounds)
+			if err != nil {
+				return 0, err
+			}
+
+			high, err = parseCronValue(lowAndHigh[1], bounds)
+			if err != nil {
+				return 0, err
+			}
+		case len(lowAndHigh) == 1:
+			low, err = parseCronValue(lowAndHigh[0], bounds)
+			if err != nil {
+				return 0, err
+			}
+
+			high = low
+			if len(rangeAndStep) == 2 {
+				high = bounds.max
+			}
+		default:
+			return 0, fmt.Errorf("invalid %s %q", bounds.name, part)
+		}
+
+		step := 1
+		if len(rangeAndStep) == 2 {
+			step, err = strconv.Atoi(rangeAndStep[1])
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid %s step %q", bounds.name, rangeAndStep[1])
+			}
+		}
+
+		if low > high {
+			return 0, fmt.Errorf("invalid %s range %q", bounds.name, part)
+		}
+
+		for value := low; value <= high; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(value string, bounds cronField) (int, error) {
+	if number, ok := bounds.names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", bounds.name, value)
+	}
+
+	if number < bounds.min || number > bounds.max {
+		return 0, fmt.Errorf("%s %d is out of range %d-%d", bounds.name, number, bounds.min, bounds.max)
+	}
+
+	return number, nil
+}
+
+func (s *cronSchedule) matchesMonth(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonth || dayOfWeek
+	}
+
+	return dayOfMonth && dayOfWeek
+}
+
+func (s *cronSchedule) matchesHour(t time.Time) bool {
+	return s.hour&(1<<uint(t.Hour())) != 0
+}
+
+func (s *cronSchedule) matchesMinute(t time.Time) bool {
+	return s.minute&(1<<uint(t.Minute())) != 0
+}
+
+// Next returns the first time after t which matches the schedule, in the
+// location of t. It returns the zero time if there is none.
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(cronSearchLimit, 0, 0)
+
+	for t.Before(limit) {
+		var next time.Time
+
+		switch {
+		case !s.matchesMonth(t):
+			next = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.matchesHour(t):
+			next = t.Add(time.Duration(60-t.Minute()) * time.Minute)
+		case !s.matchesMinute(t):
+			next = t.Add(time.Minute)
+		default:
+			return t
+		}
+
+		// Daylight saving time can move a computed wall clock time backwards
+		if !next.After(t) {
+			next = t.Add(time.Minute)
+		}
+
+		t = next
+	}
+
+	return time.Time{}
+}
+
+// Prev returns the last time at or before t which matches the schedule, in
+// the location of t. It returns the zero time if there is none.
+func (s *cronSchedule) Prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	limit := t.AddDate(-cronSearchLimit, 0, 0)
+
+	for t.After(limit) {
+		var prev time.Time
+
+		switch {
+		case !s.matchesMonth(t):
+			prev = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !s.matchesDay(t):
+			prev = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+		case !s.matchesHour(t):
+			prev = t.Add(-time.Duration(t.Minute()+1) * time.Minute)
+		case !s.matchesMinute(t):
+			prev = t.Add(-time.Minute)
+		default:
+			return t
+		}
+
+		// Daylight saving time can move a computed wall clock time forwards
+		if !prev.Before(t) {
+			prev = t.Add(-time.Minute)
+		}
+
+		t = prev
+	}
+
+	return time.Time{}
+}
+
+var _ validator.String = cronScheduleValidator{}
+
+// cronScheduleValidator validates that a string is a cron expression.
+type cronScheduleValidator struct{}
+
+func (v cronScheduleValidator) Description(ctx context.Context) string {
+	return "value must be a cron expression with the fields `minute hour day-of-month month day-of-week`"
+}
+
+func (v cronScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronScheduleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := parseCronSchedule(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Cron Expression",
+			fmt.Sprintf("The value %q is not a valid cron expression: %s.", req.ConfigValue.ValueString(), err))
+	}
+}
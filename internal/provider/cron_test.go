@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	testCases := map[string]bool{
+		"* * * * *":             true,
+		"0 8 * * 1-5":           true,
+		"*/15 8-18 * * MON-FRI": true,
+		"0 0 1,15 * *":          true,
+		"30 6 * JAN-MAR sun":    true,
+		"0 0 * * 7":             true,
+		"5/10 * * * *":          true,
+		"* * * *":               false,
+		"* * * * * *":           false,
+		"60 * * * *":            false,
+		"* 24 * * *":            false,
+		"* * 0 * *":             false,
+		"* * * 13 *":            false,
+		"* * * * 8":             false,
+		"5-1 * * * *":           false,
+		"*/0 * * * *":           false,
+		"1/2/3 * * * *":         false,
+		"a * * * *":             false,
+		"1-2-3 * * * *":         false,
+	}
+
+	for expression, valid := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			_, err := parseCronSchedule(expression)
+			if valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextPrev(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		expression string
+		now        time.Time
+		next       time.Time
+		prev       time.Time
+	}{
+		{
+			expression: "0 8 * * MON-FRI",
+			now:        time.Date(2024, 5, 17, 12, 30, 15, 0, time.UTC), // Friday
+			next:       time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC),
+			prev:       time.Date(2024, 5, 17, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			expression: "0 8 * * MON-FRI",
+			now:        time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC),
+			next:       time.Date(2024, 5, 21, 8, 0, 0, 0, time.UTC),
+			prev:       time.Date(2024, 5, 20, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			expression: "*/20 * * * *",
+			now:        time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC),
+			next:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			prev:       time.Date(2024, 12, 31, 23, 40, 0, 0, time.UTC),
+		},
+		{
+			// Either day field matches if both are restricted
+			expression: "0 0 1 * SUN",
+			now:        time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), // Saturday
+			next:       time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC),
+			prev:       time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expression: "0 0 29 2 *",
+			now:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			prev:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// 02:30 does not exist when daylight saving time starts
+			expression: "30 2 * * *",
+			now:        time.Date(2024, 3, 30, 12, 0, 0, 0, berlin),
+			next:       time.Date(2024, 4, 1, 2, 30, 0, 0, berlin),
+			prev:       time.Date(2024, 3, 30, 2, 30, 0, 0, berlin),
+		},
+		{
+			expression: "0 0 30 2 *",
+			now:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			next:       time.Time{},
+			prev:       time.Time{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expression+"@"+testCase.now.Format(time.RFC3339), func(t *testing.T) {
+			schedule, err := parseCronSchedule(testCase.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if next := schedule.Next(testCase.now); !next.Equal(testCase.next) {
+				t.Errorf("expected next %s, got %s", testCase.next, next)
+			}
+
+			if prev := schedule.Prev(testCase.now); !prev.Equal(testCase.prev) {
+				t.Errorf("expected prev %s, got %s", testCase.prev, prev)
+			}
+		})
+	}
+}
@@ -0,0 +1,535 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                     = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithConfigure        = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithImportState      = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithConfigValidators = &DefaultSecurityGroupResource{}
+	_ resource.ResourceWithModifyPlan       = &DefaultSecurityGroupResource{}
+)
+
+func NewDefaultSecurityGroupResource() resource.Resource {
+	return &DefaultSecurityGroupResource{}
+}
+
+// DefaultSecurityGroupResource defines the resource implementation.
+type DefaultSecurityGroupResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *DefaultSecurityGroupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_default_security_group"
+}
+
+func (r *DefaultSecurityGroupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Default security group resource. Adopts the existing default security group of a region instead of creating one. " +
+			"The default security group is attached to instances without `security_group_ids`. " +
+			"On destroy, the security group is left in place and its rules are restored to `baseline_rules` if `restore_on_destroy` is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"baseline_rules": schema.SetNestedAttribute{
+				MarkdownDescription: "The rules the security group is restored to on destroy. " +
+					"If not provided, the rules the security group had when it was adopted are used.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(), // immutable once adopted
+				},
+				NestedObject: securityGroupRuleNestedObject(ctx),
+			},
+			"created_at": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The timestamp when this security group was created in RFC 3339.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"description": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The human-readable description for the security group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			}),
+			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the security group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"name": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The name of the default security group in the region. " +
+					"The API does not flag the default security group, so it is looked up by this name.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(defaultSecurityGroupName),
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The region identifier.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
+				},
+			}),
+			"restore_on_destroy": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to restore the rules to `baseline_rules` on destroy. " +
+					"Defaults to `true` if `rules` is provided and to `false` otherwise, so rules managed with `genesiscloud_security_group_rule` resources are kept. " +
+					"It is `false` after an import until the next change.",
+				Optional: true,
+				Computed: true,
+			}),
+			"rules": schema.SetNestedAttribute{
+				MarkdownDescription: "The security group rules. If not provided, the rules are left unmanaged. " +
+					"Rules are compared in their canonical form, see `genesiscloud_security_group`.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(), // if unset, expect no changes
+				},
+				NestedObject: securityGroupRuleNestedObject(ctx),
+			},
+			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The security group status.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			}),
+
+			// Internal
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *DefaultSecurityGroupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		securityGroupRulesValidator{
+			attributePath: path.Root("baseline_rules"),
+		},
+		securityGroupRulesValidator{
+			attributePath: path.Root("rules"),
+		},
+	}
+}
+
+func (r *DefaultSecurityGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &configRules)...)
+
+	var restoreOnDestroy types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("restore_on_destroy"), &restoreOnDestroy)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only inline rules are restored by default, the state decides on destroy
+	if restoreOnDestroy.IsUnknown() && !configRules.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("restore_on_destroy"), !configRules.IsNull())...)
+	}
+
+	// The conflict check needs the provider to be configured
+	if r.client == nil {
+		return
+	}
+
+	var securityGroupId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &securityGroupId)...)
+
+	// The conflict is detected best effort, see SecurityGroupRulesRegistry
+	if resp.Diagnostics.HasError() || securityGroupId.IsUnknown() || configRules.IsNull() {
+		return
+	}
+
+	if r.client.SecurityGroupRules.Register(securityGroupId.ValueString(), SecurityGroupRulesInline) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rules"),
+			"Conflicting Security Group Rules",
+			fmt.Sprintf("The rules of the default security group with id %q are managed both inline and with genesiscloud_security_group_rule resources. "+
+				"Either remove the `rules` attribute or the genesiscloud_security_group_rule resources.", securityGroupId.ValueString()),
+		)
+	}
+}
+
+func (r *DefaultSecurityGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DefaultSecurityGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	securityGroup, diag := r.findDefaultSecurityGroup(ctx, genesiscloud.Region(data.Region.ValueString()), data.Name.ValueString())
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.AdoptBaselineRules(ctx, securityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &configRules)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RestoreOnDestroy.IsUnknown() {
+		data.RestoreOnDestroy = types.BoolValue(!configRules.IsNull())
+	}
+
+	if !configRules.IsNull() {
+		rules, diag := securityGroupRulesToClientRequest(ctx, data.Rules)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		securityGroup, diag = r.updateRules(ctx, securityGroup.Id, rules, "create default_security_group")
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "adopted a default security group resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if securityGroup.Status == genesiscloud.SecurityGroupStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling default_security_group", ErrResourceInErrorState))
+	}
+}
+
+func (r *DefaultSecurityGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DefaultSecurityGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	securityGroupId := data.Id.ValueString()
+
+	response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read default_security_group", err))
+		return
+	}
+
+	if response.StatusCode() == 404 {
+		tflog.Trace(ctx, "default security group is gone, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	securityGroupResponse := response.JSON200
+	if securityGroupResponse == nil {
+		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read default_security_group", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	// The baseline is unknown after an import
+	resp.Diagnostics.Append(data.AdoptBaselineRules(ctx, &securityGroupResponse.SecurityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &securityGroupResponse.SecurityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a default security group resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DefaultSecurityGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DefaultSecurityGroupResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	var configRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &configRules)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.RestoreOnDestroy.IsUnknown() {
+		data.RestoreOnDestroy = types.BoolValue(!configRules.IsNull())
+	}
+
+	// Without configured rules only the baseline or the timeouts changed
+	if configRules.IsNull() {
+		// Save updated data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	rules, diag := securityGroupRulesToClientRequest(ctx, data.Rules)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	securityGroup, diag := r.updateRules(ctx, data.Id.ValueString(), rules, "update default_security_group")
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroup)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a default security group resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if securityGroup.Status == genesiscloud.SecurityGroupStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling default_security_group", ErrResourceInErrorState))
+	}
+}
+
+func (r *DefaultSecurityGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DefaultSecurityGroupResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	// Rules not managed inline may be owned by genesiscloud_security_group_rule resources, restoring the baseline would remove them
+	if !data.RestoreOnDestroy.ValueBool() {
+		tflog.Debug(ctx, "skipped restoring the baseline rules of a default security group", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		return
+	}
+
+	// The default security group cannot be deleted, only its rules are restored
+	rules, diag := securityGroupRulesToClientRequest(ctx, data.BaselineRules)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	securityGroup, diag := r.updateRules(ctx, data.Id.ValueString(), rules, "delete default_security_group")
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	if securityGroup.Status == genesiscloud.SecurityGroupStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling default_security_group", ErrResourceInErrorState))
+	}
+}
+
+func (r *DefaultSecurityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// findDefaultSecurityGroup returns the default security group of the region.
+func (r *DefaultSecurityGroupResource) findDefaultSecurityGroup(ctx context.Context, region genesiscloud.Region, name string) (*genesiscloud.SecurityGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var found *genesiscloud.SecurityGroup
+
+	for page := 1; ; page++ {
+		response, err := r.client.ListSecurityGroupsWithResponse(ctx, &genesiscloud.ListSecurityGroupsParams{
+			Page:    pointer(page),
+			PerPage: pointer(100),
+		})
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("read security_groups", err))
+			return nil, diags
+		}
+
+		securityGroupsResponse := response.JSON200
+		if securityGroupsResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("read security_groups", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return nil, diags
+		}
+
+		for _, securityGroup := range securityGroupsResponse.SecurityGroups {
+			if securityGroup.Region != region || securityGroup.Name != name {
+				continue
+			}
+
+			// The name is not unique, so the default security group cannot be told apart from the others
+			if found != nil {
+				diags.AddError("Ambiguous Default Security Group", fmt.Sprintf("There are multiple security groups with the name %q in the region %q. "+
+					"Remove or rename the security groups which are not the default security group.", name, region))
+				return nil, diags
+			}
+
+			found = &securityGroup
+		}
+
+		if len(securityGroupsResponse.SecurityGroups) < 100 {
+			// pagination done
+			break
+		}
+	}
+
+	if found != nil {
+		return found, diags
+	}
+
+	diags.AddError("Default Security Group Not Found", fmt.Sprintf("There is no security group with the name %q in the region %q.", name, region))
+	return nil, diags
+}
+
+// updateRules replaces the rules of the security group and waits until the change is applied.
+func (r *DefaultSecurityGroupResource) updateRules(ctx context.Context, securityGroupId string, rules []genesiscloud.SecurityGroupRule, verb string) (*genesiscloud.SecurityGroup, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	unlock := r.client.SecurityGroupLocks.Lock(securityGroupId)
+	defer unlock()
+
+	body := genesiscloud.UpdateSecurityGroupJSONRequestBody{}
+	body.Rules = pointer(rules)
+
+	response, err := r.client.UpdateSecurityGroupWithResponse(ctx, securityGroupId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return nil, diags
+	}
+
+	if response.JSON200 == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling default_security_group", err))
+			return nil, diags
+		}
+
+		tflog.Trace(ctx, "polling a default security group resource")
+
+		response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("polling default_security_group", err))
+			return nil, diags
+		}
+
+		securityGroupResponse := response.JSON200
+		if securityGroupResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("polling default_security_group", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return nil, diags
+		}
+
+		status := securityGroupResponse.SecurityGroup.Status
+		if status == genesiscloud.SecurityGroupStatusCreated || status == genesiscloud.SecurityGroupStatusError {
+			return &securityGroupResponse.SecurityGroup, diags
+		}
+	}
+}
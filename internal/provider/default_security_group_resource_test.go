@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccDefaultSecurityGroupResourceConfig(port int) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_default_security_group" "test" {
+  region = "NORD-NO-KRS-1"
+  rules = [
+    {
+      direction      = "ingress"
+      protocol       = "tcp"
+      port_range_min = %[1]d
+      port_range_max = %[1]d
+    }
+  ]
+}
+`, port)
+}
+
+func TestAccDefaultSecurityGroupResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccDefaultSecurityGroupResourceConfig(22),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_default_security_group.test", "name", "standard"),
+					resource.TestCheckResourceAttr("genesiscloud_default_security_group.test", "rules.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "genesiscloud_default_security_group.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"baseline_rules"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccDefaultSecurityGroupResourceConfig(2222),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_default_security_group.test", "rules.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
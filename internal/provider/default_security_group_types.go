@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultSecurityGroupName is the name of the security group every region provides
+// and which is attached to instances without `security_group_ids`. The API has no
+// flag for the default security group, so it is identified by the name the API
+// gives it when the region is first used. The name can be overridden with `name`.
+const defaultSecurityGroupName = "standard"
+
+type DefaultSecurityGroupResourceModel struct {
+	// BaselineRules The rules the security group is restored to on destroy.
+	BaselineRules types.Set `tfsdk:"baseline_rules"`
+
+	CreatedAt types.String `tfsdk:"created_at"`
+
+	// Description The human-readable description for the security group.
+	Description types.String `tfsdk:"description"`
+
+	// Id The unique ID of the security group.
+	Id types.String `tfsdk:"id"`
+
+	// Name The human-readable name for the security group.
+	Name types.String `tfsdk:"name"`
+
+	// Region The region identifier.
+	Region types.String `tfsdk:"region"`
+
+	// RestoreOnDestroy Flag to restore the rules to the baseline on destroy.
+	RestoreOnDestroy types.Bool `tfsdk:"restore_on_destroy"`
+
+	// Rules The security group rules.
+	Rules types.Set `tfsdk:"rules"`
+
+	// Status The security group status.
+	Status types.String `tfsdk:"status"`
+
+	// Internal
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (data *DefaultSecurityGroupResourceModel) PopulateFromClientResponse(ctx context.Context, securityGroup *genesiscloud.SecurityGroup) (diag diag.Diagnostics) {
+	data.CreatedAt = types.StringValue(securityGroup.CreatedAt.Format(time.RFC3339))
+	data.Description = types.StringValue(securityGroup.Description)
+	data.Id = types.StringValue(securityGroup.Id)
+	data.Name = types.StringValue(securityGroup.Name)
+	data.Region = types.StringValue(string(securityGroup.Region))
+
+	data.Rules, diag = securityGroupRulesFromClientResponse(ctx, data.Rules, securityGroup.Rules)
+	if diag.HasError() {
+		return
+	}
+
+	data.Status = types.StringValue(string(securityGroup.Status))
+
+	return
+}
+
+// AdoptBaselineRules sets the baseline to the current rules of the security group unless it is configured.
+func (data *DefaultSecurityGroupResourceModel) AdoptBaselineRules(ctx context.Context, securityGroup *genesiscloud.SecurityGroup) (diag diag.Diagnostics) {
+	if !data.BaselineRules.IsNull() && !data.BaselineRules.IsUnknown() {
+		return
+	}
+
+	data.BaselineRules, diag = securityGroupRulesFromClientResponse(ctx, types.SetNull(securityGroupRuleObjectType), securityGroup.Rules)
+
+	return
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDefaultSecurityGroupResourceModifyPlanRestoreOnDestroy(t *testing.T) {
+	ctx := context.Background()
+
+	r := &DefaultSecurityGroupResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	timeoutsType := schemaResp.Schema.Attributes["timeouts"].GetType().(timeouts.Type)
+
+	rules := types.SetValueMust(securityGroupRuleObjectType, []attr.Value{})
+
+	testCases := map[string]struct {
+		rules            types.Set
+		restoreOnDestroy types.Bool
+		expected         bool
+	}{
+		"inline rules": {
+			rules:            rules,
+			restoreOnDestroy: types.BoolNull(),
+			expected:         true,
+		},
+		"unmanaged rules": {
+			rules:            types.SetNull(securityGroupRuleObjectType),
+			restoreOnDestroy: types.BoolNull(),
+			expected:         false,
+		},
+		"configured": {
+			rules:            rules,
+			restoreOnDestroy: types.BoolValue(false),
+			expected:         false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := DefaultSecurityGroupResourceModel{
+				BaselineRules:    types.SetNull(securityGroupRuleObjectType),
+				CreatedAt:        types.StringNull(),
+				Description:      types.StringNull(),
+				Id:               types.StringNull(),
+				Name:             types.StringNull(),
+				Region:           types.StringValue("NORD-NO-KRS-1"),
+				RestoreOnDestroy: testCase.restoreOnDestroy,
+				Rules:            testCase.rules,
+				Status:           types.StringNull(),
+				Timeouts:         timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+			}
+
+			config := tfsdk.Plan{Schema: schemaResp.Schema}
+			diags := config.Set(ctx, &data)
+
+			if data.RestoreOnDestroy.IsNull() {
+				data.RestoreOnDestroy = types.BoolUnknown()
+			}
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			diags.Append(plan.Set(ctx, &data)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			req := resource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+				Plan:   plan,
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: plan.Raw.Copy()},
+			}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+
+			r.ModifyPlan(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var restoreOnDestroy types.Bool
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("restore_on_destroy"), &restoreOnDestroy)...)
+			if restoreOnDestroy.IsUnknown() || restoreOnDestroy.ValueBool() != testCase.expected {
+				t.Errorf("expected %t, got %s", testCase.expected, restoreOnDestroy)
+			}
+		})
+	}
+}
+
+func TestDefaultSecurityGroupResourceDeleteKeepsRules(t *testing.T) {
+	ctx := context.Background()
+
+	// Without a client, restoring the baseline would fail
+	r := &DefaultSecurityGroupResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	timeoutsType := schemaResp.Schema.Attributes["timeouts"].GetType().(timeouts.Type)
+
+	data := DefaultSecurityGroupResourceModel{
+		BaselineRules:    types.SetValueMust(securityGroupRuleObjectType, []attr.Value{}),
+		CreatedAt:        types.StringNull(),
+		Description:      types.StringNull(),
+		Id:               types.StringValue("sg-1"),
+		Name:             types.StringValue(defaultSecurityGroupName),
+		Region:           types.StringValue("NORD-NO-KRS-1"),
+		RestoreOnDestroy: types.BoolValue(false),
+		Rules:            types.SetValueMust(securityGroupRuleObjectType, []attr.Value{}),
+		Status:           types.StringNull(),
+		Timeouts:         timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags := state.Set(ctx, &data)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema of the `deletion_protection` attribute.
+func deletionProtectionAttribute(ctx context.Context, resourceName string) schema.Attribute {
+	return resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+		MarkdownDescription: fmt.Sprintf("Flag to protect the %s from being destroyed or replaced. "+
+			"It has to be disabled and applied before the %s can be destroyed or replaced.", resourceName, resourceName),
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.Bool{
+			defaultplanmodifier.Bool(false),
+		},
+	})
+}
+
+// deletionProtectionPlanDiagnostics returns an error if the plan destroys or
+// replaces a resource whose prior state has `deletion_protection` enabled. The
+// prior state is used on purpose, so disabling the protection has to be applied
+// first. The changes map the attributes that require replacement to whether
+// their planned value differs from the prior state.
+func deletionProtectionPlanDiagnostics(resourceName string, destroy bool, changes map[string]bool) (diags diag.Diagnostics) {
+	if destroy {
+		diags.AddError(
+			"Deletion Protection",
+			fmt.Sprintf("The %s has deletion_protection enabled and cannot be destroyed. "+
+				"Set deletion_protection = false and apply the change before destroying it.", resourceName),
+		)
+		return
+	}
+
+	var changed []string
+	for attribute, hasChanged := range changes {
+		if hasChanged {
+			changed = append(changed, attribute)
+		}
+	}
+
+	if len(changed) == 0 {
+		return
+	}
+
+	sort.Strings(changed)
+
+	diags.AddError(
+		"Deletion Protection",
+		fmt.Sprintf("The %s has deletion_protection enabled and cannot be replaced. The replacement is caused by a change of %s. "+
+			"Set deletion_protection = false and apply the change before replacing it.", resourceName, strings.Join(changed, ", ")),
+	)
+
+	return
+}
+
+// deletionProtectionResizablePlan returns an error if the plan destroys or
+// replaces a volume or filesystem, named by resourceName, whose prior state has
+// `deletion_protection` enabled. Besides a change of the attributes, decreasing
+// the `size` replaces it if `replace_on_shrink` is set. The prior state must not
+// be null.
+func deletionProtectionResizablePlan(ctx context.Context, resourceName string, req resource.ModifyPlanRequest, attributes []path.Path) (diags diag.Diagnostics) {
+	var deletionProtection types.Bool
+	diags.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if diags.HasError() || !deletionProtection.ValueBool() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		return deletionProtectionPlanDiagnostics(resourceName, true, nil)
+	}
+
+	changes, diags := deletionProtectionChanges(ctx, req.Plan, req.State, attributes)
+	if diags.HasError() {
+		return
+	}
+
+	var replaceOnShrink types.Bool
+	var planSize, stateSize types.Int64
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("replace_on_shrink"), &replaceOnShrink)...)
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("size"), &planSize)...)
+	diags.Append(req.State.GetAttribute(ctx, path.Root("size"), &stateSize)...)
+	if diags.HasError() {
+		return
+	}
+
+	// The size only requires replacement if it is decreased and replace_on_shrink is set
+	changes["size"] = replaceOnShrink.ValueBool() && !planSize.IsUnknown() && planSize.ValueInt64() < stateSize.ValueInt64()
+
+	diags.Append(deletionProtectionPlanDiagnostics(resourceName, false, changes)...)
+
+	return
+}
+
+// deletionProtectionError returns the error details for a Delete call on a protected resource.
+func deletionProtectionError(resourceName string, id string) string {
+	return fmt.Sprintf("The %s with id %q has deletion_protection enabled and was not deleted. "+
+		"Set deletion_protection = false and apply the change before destroying it.", resourceName, id)
+}
+
+// deletionProtectionChanges maps the attributes which require replacement of
+// the resource to whether their planned value differs from the prior state.
+// The attributes are listed per resource, because the plan modifiers which
+// require replacement, e.g. RequiresReplaceIfConfigured, are not visible here.
+func deletionProtectionChanges(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, attributes []path.Path) (changes map[string]bool, diags diag.Diagnostics) {
+	changes = map[string]bool{}
+
+	for _, attributePath := range attributes {
+		var planValue, stateValue attr.Value
+
+		diags.Append(plan.GetAttribute(ctx, attributePath, &planValue)...)
+		diags.Append(state.GetAttribute(ctx, attributePath, &stateValue)...)
+		if diags.HasError() {
+			return
+		}
+
+		changes[attributePath.String()] = !planValue.Equal(stateValue)
+	}
+
+	return
+}
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// planModifierDescribers converts the plan modifiers of an attribute type.
+func planModifierDescribers[T planmodifier.Describer](modifiers []T) []planmodifier.Describer {
+	describers := make([]planmodifier.Describer, 0, len(modifiers))
+	for _, modifier := range modifiers {
+		describers = append(describers, modifier)
+	}
+	return describers
+}
+
+// attributePlanModifiers returns the plan modifiers of an attribute.
+func attributePlanModifiers(attribute schema.Attribute) []planmodifier.Describer {
+	switch attribute := attribute.(type) {
+	case schema.BoolAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.Int64Attribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.StringAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.ListAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.SetAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.SingleNestedAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.ListNestedAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	case schema.SetNestedAttribute:
+		return planModifierDescribers(attribute.PlanModifiers)
+	}
+	return nil
+}
+
+// describedReplaceAttributes returns the paths of the attributes whose plan
+// modifiers or documentation state that a change replaces the resource. This
+// includes conditional replacements, e.g. RequiresReplaceIfConfigured.
+func describedReplaceAttributes(ctx context.Context, attributes map[string]schema.Attribute, parent path.Path) []string {
+	var paths []string
+
+	for name, attribute := range attributes {
+		attributePath := parent.AtName(name)
+
+		descriptions := []string{attribute.GetMarkdownDescription()}
+		for _, modifier := range attributePlanModifiers(attribute) {
+			descriptions = append(descriptions, modifier.Description(ctx))
+		}
+
+		for _, description := range descriptions {
+			if strings.Contains(description, "destroy and recreate the resource") || strings.Contains(description, "the resource will be replaced") {
+				paths = append(paths, attributePath.String())
+				break
+			}
+		}
+
+		if nested, ok := attribute.(schema.SingleNestedAttribute); ok {
+			paths = append(paths, describedReplaceAttributes(ctx, nested.Attributes, attributePath)...)
+		}
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
+func TestDeletionProtectionCoversRequiresReplace(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		resource   resource.Resource
+		attributes []path.Path
+		includes   []string
+	}{
+		"filesystem": {
+			resource:   NewFilesystemResource(),
+			attributes: filesystemReplaceAttributes,
+			includes:   []string{"region", "type"},
+		},
+		"instance": {
+			resource:   NewInstanceResource(),
+			attributes: instanceReplaceAttributes,
+			includes:   []string{"hostname", "image", "metadata.startup_script", "region", "type"},
+		},
+		"volume": {
+			resource:   NewVolumeResource(),
+			attributes: volumeReplaceAttributes,
+			includes:   []string{"region", "source_snapshot_id", "source_volume_id", "type"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			testCase.resource.Schema(ctx, resource.SchemaRequest{}, resp)
+
+			var protected []string
+			for _, attributePath := range testCase.attributes {
+				protected = append(protected, attributePath.String())
+			}
+			sort.Strings(protected)
+
+			described := describedReplaceAttributes(ctx, resp.Schema.Attributes, path.Empty())
+
+			if strings.Join(protected, ",") != strings.Join(described, ",") {
+				t.Errorf("expected the protected attributes %v to match the attributes which require replacement %v", protected, described)
+			}
+
+			for _, attributePath := range testCase.includes {
+				if !slices.Contains(protected, attributePath) {
+					t.Errorf("the attribute %q requires replacement but is not protected", attributePath)
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceResourceModifyPlanDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+
+	r := &InstanceResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		hostname string
+		name     string
+		expected string
+	}{
+		"unchanged": {
+			hostname: "web",
+			name:     "web",
+		},
+		"name changed": {
+			hostname: "web",
+			name:     "api",
+		},
+		"hostname changed": {
+			hostname: "api",
+			name:     "web",
+			expected: "caused by a change of hostname.",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			diags := state.SetAttribute(ctx, path.Root("deletion_protection"), true)
+			diags.Append(state.SetAttribute(ctx, path.Root("hostname"), "web")...)
+			diags.Append(state.SetAttribute(ctx, path.Root("name"), "web")...)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			diags.Append(plan.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("hostname"), testCase.hostname)...)
+			diags.Append(plan.SetAttribute(ctx, path.Root("name"), testCase.name)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+
+			if testCase.expected == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), testCase.expected) {
+				t.Errorf("expected an error containing %q, got %v", testCase.expected, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDeletionProtectionChanges(t *testing.T) {
+	ctx := context.Background()
+
+	type nestedModel struct {
+		Replace types.String `tfsdk:"replace"`
+	}
+
+	type model struct {
+		Name    types.String `tfsdk:"name"`
+		Region  types.String `tfsdk:"region"`
+		Nested  *nestedModel `tfsdk:"nested"`
+		Updated types.String `tfsdk:"updated"`
+	}
+
+	requiresReplace := []planmodifier.String{stringplanmodifier.RequiresReplace()}
+
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name":   schema.StringAttribute{Optional: true},
+			"region": schema.StringAttribute{Optional: true, PlanModifiers: requiresReplace},
+			"nested": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"replace": schema.StringAttribute{Optional: true, PlanModifiers: requiresReplace},
+				},
+			},
+			"updated": schema.StringAttribute{Optional: true, PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+		},
+	}
+
+	testCases := map[string]struct {
+		state, plan model
+		changed     string
+	}{
+		"unchanged": {
+			state: model{Name: types.StringValue("a"), Region: types.StringValue("x")},
+			plan:  model{Name: types.StringValue("b"), Region: types.StringValue("x"), Updated: types.StringValue("u")},
+		},
+		"region": {
+			state:   model{Region: types.StringValue("x")},
+			plan:    model{Region: types.StringValue("y")},
+			changed: "region",
+		},
+		"nested": {
+			state:   model{Nested: &nestedModel{Replace: types.StringValue("x")}},
+			plan:    model{},
+			changed: "nested.replace",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: testSchema}
+			plan := tfsdk.Plan{Schema: testSchema}
+
+			diags := state.Set(ctx, &testCase.state)
+			diags.Append(plan.Set(ctx, &testCase.plan)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			changes, diags := deletionProtectionChanges(ctx, plan, state, []path.Path{path.Root("region"), path.Root("nested").AtName("replace")})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if len(changes) != 2 {
+				t.Errorf("expected the region and nested.replace attributes, got %v", changes)
+			}
+
+			var changed []string
+			for attribute, hasChanged := range changes {
+				if hasChanged {
+					changed = append(changed, attribute)
+				}
+			}
+
+			if strings.Join(changed, ",") != testCase.changed {
+				t.Errorf("expected the changes %q, got %v", testCase.changed, changed)
+			}
+		})
+	}
+}
+
+func TestDeletionProtectionResizablePlan(t *testing.T) {
+	ctx := context.Background()
+
+	r := &FilesystemResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	objectType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		deletionProtection bool
+		destroy            bool
+		region             string
+		size               int64
+		replaceOnShrink    bool
+		expected           string
+	}{
+		"unprotected": {
+			destroy: true,
+		},
+		"destroy": {
+			deletionProtection: true,
+			destroy:            true,
+			expected:           "cannot be destroyed",
+		},
+		"grow": {
+			deletionProtection: true,
+			size:               200,
+		},
+		"shrink": {
+			deletionProtection: true,
+			size:               50,
+		},
+		"shrink with replace_on_shrink": {
+			deletionProtection: true,
+			size:               50,
+			replaceOnShrink:    true,
+			expected:           "caused by a change of size.",
+		},
+		"region": {
+			deletionProtection: true,
+			region:             "EUW-NL-AMS-1",
+			expected:           "caused by a change of region.",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			diags := state.SetAttribute(ctx, path.Root("deletion_protection"), testCase.deletionProtection)
+			diags.Append(state.SetAttribute(ctx, path.Root("region"), "NORD-NO-KRS-1")...)
+			diags.Append(state.SetAttribute(ctx, path.Root("size"), int64(100))...)
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+			if !testCase.destroy {
+				region := "NORD-NO-KRS-1"
+				if testCase.region != "" {
+					region = testCase.region
+				}
+
+				diags.Append(plan.SetAttribute(ctx, path.Root("deletion_protection"), testCase.deletionProtection)...)
+				diags.Append(plan.SetAttribute(ctx, path.Root("region"), region)...)
+				diags.Append(plan.SetAttribute(ctx, path.Root("replace_on_shrink"), testCase.replaceOnShrink)...)
+				diags.Append(plan.SetAttribute(ctx, path.Root("size"), testCase.size)...)
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			diags = deletionProtectionResizablePlan(ctx, "filesystem", resource.ModifyPlanRequest{Plan: plan, State: state}, filesystemReplaceAttributes)
+
+			if testCase.expected == "" {
+				if diags.HasError() {
+					t.Errorf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), testCase.expected) {
+				t.Errorf("expected an error containing %q, got %v", testCase.expected, diags)
+			}
+		})
+	}
+}
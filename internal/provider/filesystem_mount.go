@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/genesiscloud/genesiscloud-go"
+)
+
+// filesystemMountOptions are the NFS mount options of a vast filesystem.
+const filesystemMountOptions = "vers=3,nconnect=16"
+
+// filesystemMountPointRegex matches the mount points which can be used
+// unquoted in a shell command and in fstab.
+var filesystemMountPointRegex = regexp.MustCompile(`^/[a-zA-Z0-9._/-]+$`)
+
+var filesystemMountPointUnsafeRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// ErrFilesystemNotMountable is returned while the filesystem is being created.
+var ErrFilesystemNotMountable = errors.New("the filesystem has no mount endpoint yet")
+
+// defaultFilesystemMountPoint returns `/mnt/` followed by the filesystem name
+// with the characters which are not allowed in a mount point replaced by `-`.
+func defaultFilesystemMountPoint(name string) string {
+	name = strings.Trim(filesystemMountPointUnsafeRegex.ReplaceAllString(name, "-"), "-")
+	if name == "" || name == "." || name == ".." {
+		name = "filesystem"
+	}
+
+	return "/mnt/" + name
+}
+
+// filesystemMount describes how a filesystem is mounted on an instance.
+type filesystemMount struct {
+	// Source The remote filesystem, e.g. `10.0.0.1:/base/path`.
+	Source string
+
+	// MountPoint The local directory the filesystem is mounted at.
+	MountPoint string
+
+	// FsType The filesystem type passed to mount, e.g. `nfs`.
+	FsType string
+
+	// Options The mount options.
+	Options string
+}
+
+// newFilesystemMount returns the mount of the filesystem at the mount point.
+// The filesystem is mounted from the first address of its mount endpoint range.
+func newFilesystemMount(filesystem *genesiscloud.Filesystem, mountPoint string) (*filesystemMount, error) {
+	if !filesystemMountPointRegex.MatchString(mountPoint) {
+		return nil, fmt.Errorf("the mount point %q must be an absolute path of letters, digits, `.`, `_`, `-` and `/`", mountPoint)
+	}
+
+	switch filesystem.Type {
+	case "vast":
+		if filesystem.MountBasePath == nil || *filesystem.MountBasePath == "" || len(filesystem.MountEndpointRange) == 0 {
+			return nil, ErrFilesystemNotMountable
+		}
+
+		return &filesystemMount{
+			Source:     filesystem.MountEndpointRange[0] + ":" + *filesystem.MountBasePath,
+			MountPoint: mountPoint,
+			FsType:     "nfs",
+			Options:    filesystemMountOptions,
+		}, nil
+	default:
+		return nil, fmt.Errorf("mounting filesystems of type %q is not supported", filesystem.Type)
+	}
+}
+
+// Command returns a shell command which creates the mount point and mounts the filesystem.
+func (m *filesystemMount) Command() string {
+	return fmt.Sprintf("mkdir -p %s && mount -t %s -o %s %s %s", m.MountPoint, m.FsType, m.Options, m.Source, m.MountPoint)
+}
+
+// FstabEntry returns the line of /etc/fstab which mounts the filesystem on boot.
+// The boot continues if the filesystem cannot be mounted.
+func (m *filesystemMount) FstabEntry() string {
+	return fmt.Sprintf("%s %s %s %s,_netdev,nofail 0 0", m.Source, m.MountPoint, m.FsType, m.Options)
+}
+
+// filesystemMountsCloudInit returns the `mounts` and `runcmd` keys of a
+// cloud-config which mount the filesystems. The mounts are retried in runcmd,
+// as the network might not be up yet when cloud-init processes the mounts.
+func filesystemMountsCloudInit(mounts []filesystemMount) string {
+	var b strings.Builder
+
+	b.WriteString("mounts:\n")
+	for _, m := range mounts {
+		fmt.Fprintf(&b, "  - [ %q, %q, %q, %q, \"0\", \"0\" ]\n", m.Source, m.MountPoint, m.FsType, m.Options+",_netdev,nofail")
+	}
+
+	b.WriteString("runcmd:\n")
+	for _, m := range mounts {
+		fmt.Fprintf(&b, "  - [ mkdir, -p, %q ]\n", m.MountPoint)
+		fmt.Fprintf(&b, "  - [ sh, -c, %q ]\n", fmt.Sprintf("mountpoint -q %s || mount %s", m.MountPoint, m.MountPoint))
+	}
+
+	return b.String()
+}
+
+// filesystemMountsCloudConfig returns a cloud-config which mounts the filesystems.
+func filesystemMountsCloudConfig(mounts []filesystemMount) string {
+	return "#cloud-config\n" + filesystemMountsCloudInit(mounts)
+}
+
+// filesystemMountsScript returns a bash script which adds the filesystems to
+// /etc/fstab, unless their mount point is already in there, and mounts them.
+func filesystemMountsScript(mounts []filesystemMount) string {
+	var b strings.Builder
+
+	b.WriteString("#!/bin/bash\nset -euo pipefail\n")
+	for _, m := range mounts {
+		fmt.Fprintf(&b, "\nmkdir -p %s\n", m.MountPoint)
+		fmt.Fprintf(&b, "grep -qF ' %s ' /etc/fstab || echo '%s' >> /etc/fstab\n", m.MountPoint, m.FstabEntry())
+		fmt.Fprintf(&b, "mountpoint -q %s || mount %s\n", m.MountPoint, m.MountPoint)
+	}
+
+	return b.String()
+}
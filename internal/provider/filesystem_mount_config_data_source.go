@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/datasourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource              = &FilesystemMountConfigDataSource{}
+	_ datasource.DataSourceWithConfigure = &FilesystemMountConfigDataSource{}
+)
+
+func NewFilesystemMountConfigDataSource() datasource.DataSource {
+	return &FilesystemMountConfigDataSource{}
+}
+
+// FilesystemMountConfigDataSource defines the data source implementation.
+type FilesystemMountConfigDataSource struct {
+	DataSourceWithClient
+	DataSourceWithTimeout
+}
+
+func (d *FilesystemMountConfigDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_filesystem_mount_config"
+}
+
+func (d *FilesystemMountConfigDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Filesystem mount config data source. Renders a `cloud_init` and a `script` which mount the filesystems on an instance. " +
+			"Both can be used as the `metadata.startup_script` of a `genesiscloud_instance`.",
+
+		Attributes: map[string]schema.Attribute{
+			"filesystems": schema.ListNestedAttribute{
+				MarkdownDescription: "The filesystems to mount.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The id of the filesystem.",
+							Required:            true,
+						}),
+						"mount_command": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The shell command which creates `mount_point` and mounts the filesystem at it. It has to be run as root.",
+							Computed:            true,
+						}),
+						"mount_fstab_entry": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The line of `/etc/fstab` which mounts the filesystem at `mount_point` on boot.",
+							Computed:            true,
+						}),
+						"mount_point": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+							MarkdownDescription: "The local directory of the instance the filesystem is mounted at. " +
+								"Defaults to `/mnt/` followed by the name of the filesystem, with the characters which are not allowed replaced by `-`.",
+							Optional: true,
+							Computed: true,
+							Validators: []validator.String{
+								mountPointValidator{},
+							},
+						}),
+					},
+				},
+			},
+			"cloud_init": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The cloud-config which adds the filesystems to `mounts` and mounts them in `runcmd`.",
+				Computed:            true,
+			}),
+			"script": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The bash script which adds the filesystems to `/etc/fstab` and mounts them.",
+				Computed:            true,
+			}),
+			"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source itself.",
+				Computed:            true,
+			}),
+
+			// Internal
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *FilesystemMountConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FilesystemMountConfigDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	filesystems := make([]genesiscloud.Filesystem, 0, len(data.Filesystems))
+
+	for _, model := range data.Filesystems {
+		filesystem, diag := getFilesystem(ctx, d.client, model.Id.ValueString(), "read filesystem")
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		filesystems = append(filesystems, *filesystem)
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(filesystems)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue("none")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
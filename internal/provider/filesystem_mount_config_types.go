@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type FilesystemMountConfigFilesystemModel struct {
+	// Id The unique ID of the filesystem.
+	Id types.String `tfsdk:"id"`
+
+	// MountCommand The shell command which mounts the filesystem.
+	MountCommand types.String `tfsdk:"mount_command"`
+
+	// MountFstabEntry The line of /etc/fstab which mounts the filesystem.
+	MountFstabEntry types.String `tfsdk:"mount_fstab_entry"`
+
+	// MountPoint The local directory the filesystem is mounted at.
+	MountPoint types.String `tfsdk:"mount_point"`
+}
+
+// FilesystemMountConfigDataSourceModel describes the data source data model.
+type FilesystemMountConfigDataSourceModel struct {
+	Filesystems []FilesystemMountConfigFilesystemModel `tfsdk:"filesystems"`
+
+	// CloudInit The cloud-config which mounts the filesystems.
+	CloudInit types.String `tfsdk:"cloud_init"`
+
+	// Script The bash script which mounts the filesystems.
+	Script types.String `tfsdk:"script"`
+
+	Id types.String `tfsdk:"id"` // placeholder
+
+	// Internal
+
+	// Timeouts The data source timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// PopulateFromClientResponse renders the mount configuration of the
+// filesystems, which are given in the order of the filesystems attribute.
+func (data *FilesystemMountConfigDataSourceModel) PopulateFromClientResponse(filesystems []genesiscloud.Filesystem) (diags diag.Diagnostics) {
+	mounts := make([]filesystemMount, 0, len(filesystems))
+	mountPoints := map[string]int{}
+
+	for i := range filesystems {
+		model := &data.Filesystems[i]
+
+		if model.MountPoint.IsNull() || model.MountPoint.IsUnknown() {
+			model.MountPoint = types.StringValue(defaultFilesystemMountPoint(filesystems[i].Name))
+		}
+
+		mountPoint := model.MountPoint.ValueString()
+
+		if j, ok := mountPoints[mountPoint]; ok {
+			diags.AddAttributeError(path.Root("filesystems").AtListIndex(i).AtName("mount_point"), "Duplicate Mount Point",
+				fmt.Sprintf("The filesystems at index %d and %d are both mounted at %q. Set a distinct mount_point.", j, i, mountPoint))
+			continue
+		}
+		mountPoints[mountPoint] = i
+
+		mount, err := newFilesystemMount(&filesystems[i], mountPoint)
+		if err != nil {
+			diags.AddAttributeError(path.Root("filesystems").AtListIndex(i).AtName("id"), "Filesystem Not Mountable",
+				fmt.Sprintf("The filesystem with id %q cannot be mounted: %s.", filesystems[i].Id, err))
+			continue
+		}
+
+		model.MountCommand = types.StringValue(mount.Command())
+		model.MountFstabEntry = types.StringValue(mount.FstabEntry())
+
+		mounts = append(mounts, *mount)
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	data.CloudInit = types.StringValue(filesystemMountsCloudConfig(mounts))
+	data.Script = types.StringValue(filesystemMountsScript(mounts))
+
+	return
+}
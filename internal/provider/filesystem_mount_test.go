@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testMountFilesystem(id string, name string) genesiscloud.Filesystem {
+	return genesiscloud.Filesystem{
+		Id:                 id,
+		Name:               name,
+		MountBasePath:      pointer("/" + id),
+		MountEndpointRange: []string{"10.0.0.1", "10.0.0.16"},
+		Type:               "vast",
+	}
+}
+
+func TestDefaultFilesystemMountPoint(t *testing.T) {
+	tests := map[string]string{
+		"data":            "/mnt/data",
+		"My Data (2024)":  "/mnt/My-Data-2024",
+		"training_set.v2": "/mnt/training_set.v2",
+		"../etc":          "/mnt/..-etc",
+		"..":              "/mnt/filesystem",
+		"???":             "/mnt/filesystem",
+	}
+
+	for name, expected := range tests {
+		if mountPoint := defaultFilesystemMountPoint(name); mountPoint != expected {
+			t.Errorf("%q: expected %q, got %q", name, expected, mountPoint)
+		}
+	}
+}
+
+func TestNewFilesystemMount(t *testing.T) {
+	filesystem := testMountFilesystem("data", "data")
+
+	mount, err := newFilesystemMount(&filesystem, "/mnt/data")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if command := mount.Command(); command != "mkdir -p /mnt/data && mount -t nfs -o vers=3,nconnect=16 10.0.0.1:/data /mnt/data" {
+		t.Errorf("unexpected command %q", command)
+	}
+
+	if entry := mount.FstabEntry(); entry != "10.0.0.1:/data /mnt/data nfs vers=3,nconnect=16,_netdev,nofail 0 0" {
+		t.Errorf("unexpected fstab entry %q", entry)
+	}
+
+	expected := `mounts:
+  - [ "10.0.0.1:/data", "/mnt/data", "nfs", "vers=3,nconnect=16,_netdev,nofail", "0", "0" ]
+runcmd:
+  - [ mkdir, -p, "/mnt/data" ]
+  - [ sh, -c, "mountpoint -q /mnt/data || mount /mnt/data" ]
+`
+	if cloudInit := filesystemMountsCloudInit([]filesystemMount{*mount}); cloudInit != expected {
+		t.Errorf("unexpected cloud-init:\n%s", cloudInit)
+	}
+}
+
+func TestNewFilesystemMountErrors(t *testing.T) {
+	filesystem := testMountFilesystem("data", "data")
+
+	if _, err := newFilesystemMount(&filesystem, "mnt/data"); err == nil {
+		t.Error("expected an error for a relative mount point")
+	}
+
+	if _, err := newFilesystemMount(&filesystem, "/mnt/my data"); err == nil {
+		t.Error("expected an error for a mount point with whitespace")
+	}
+
+	creating := testMountFilesystem("data", "data")
+	creating.MountBasePath = nil
+	creating.MountEndpointRange = nil
+
+	if _, err := newFilesystemMount(&creating, "/mnt/data"); !errors.Is(err, ErrFilesystemNotMountable) {
+		t.Errorf("expected ErrFilesystemNotMountable, got %v", err)
+	}
+
+	unsupported := testMountFilesystem("data", "data")
+	unsupported.Type = "other"
+
+	if _, err := newFilesystemMount(&unsupported, "/mnt/data"); err == nil {
+		t.Error("expected an error for an unsupported type")
+	}
+}
+
+func TestFilesystemMountConfigPopulate(t *testing.T) {
+	data := FilesystemMountConfigDataSourceModel{
+		Filesystems: []FilesystemMountConfigFilesystemModel{
+			{Id: types.StringValue("data"), MountPoint: types.StringNull()},
+			{Id: types.StringValue("models"), MountPoint: types.StringValue("/models")},
+		},
+	}
+
+	diags := data.PopulateFromClientResponse([]genesiscloud.Filesystem{
+		testMountFilesystem("data", "data"),
+		testMountFilesystem("models", "models"),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if mountPoint := data.Filesystems[0].MountPoint.ValueString(); mountPoint != "/mnt/data" {
+		t.Errorf("unexpected default mount point %q", mountPoint)
+	}
+
+	if entry := data.Filesystems[1].MountFstabEntry.ValueString(); entry != "10.0.0.1:/models /models nfs vers=3,nconnect=16,_netdev,nofail 0 0" {
+		t.Errorf("unexpected fstab entry %q", entry)
+	}
+
+	expected := `#!/bin/bash
+set -euo pipefail
+
+mkdir -p /mnt/data
+grep -qF ' /mnt/data ' /etc/fstab || echo '10.0.0.1:/data /mnt/data nfs vers=3,nconnect=16,_netdev,nofail 0 0' >> /etc/fstab
+mountpoint -q /mnt/data || mount /mnt/data
+
+mkdir -p /models
+grep -qF ' /models ' /etc/fstab || echo '10.0.0.1:/models /models nfs vers=3,nconnect=16,_netdev,nofail 0 0' >> /etc/fstab
+mountpoint -q /models || mount /models
+`
+	if script := data.Script.ValueString(); script != expected {
+		t.Errorf("unexpected script:\n%s", script)
+	}
+
+	if cloudInit := data.CloudInit.ValueString(); !strings.HasPrefix(cloudInit, "#cloud-config\nmounts:\n") {
+		t.Errorf("unexpected cloud-init:\n%s", cloudInit)
+	}
+}
+
+func TestFilesystemMountConfigPopulateDuplicateMountPoint(t *testing.T) {
+	data := FilesystemMountConfigDataSourceModel{
+		Filesystems: []FilesystemMountConfigFilesystemModel{
+			{Id: types.StringValue("one"), MountPoint: types.StringNull()},
+			{Id: types.StringValue("two"), MountPoint: types.StringValue("/mnt/data")},
+		},
+	}
+
+	diags := data.PopulateFromClientResponse([]genesiscloud.Filesystem{
+		testMountFilesystem("one", "data"),
+		testMountFilesystem("two", "other"),
+	})
+	if diags.ErrorsCount() != 1 {
+		t.Errorf("expected a duplicate mount point error, got %v", diags)
+	}
+
+	if !data.Script.IsNull() {
+		t.Errorf("expected no script, got %q", data.Script.ValueString())
+	}
+}
@@ -26,8 +26,16 @@ var (
 	_ resource.Resource                = &FilesystemResource{}
 	_ resource.ResourceWithConfigure   = &FilesystemResource{}
 	_ resource.ResourceWithImportState = &FilesystemResource{}
+	_ resource.ResourceWithModifyPlan  = &FilesystemResource{}
 )
 
+// filesystemReplaceAttributes are the attributes whose change replaces the filesystem,
+// which the deletion protection prevents. They have to match the schema.
+var filesystemReplaceAttributes = []path.Path{
+	path.Root("region"),
+	path.Root("type"),
+}
+
 func NewFilesystemResource() resource.Resource {
 	return &FilesystemResource{}
 }
@@ -63,6 +71,7 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 					defaultplanmodifier.String(""),
 				},
 			}),
+			"deletion_protection": deletionProtectionAttribute(ctx, "filesystem"),
 			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "A unique identifier for each filesystem. This is automatically generated.",
 				Computed:            true,
@@ -84,9 +93,20 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
 				},
 			}),
+			"replace_on_shrink": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to replace the filesystem if its `size` is decreased, which deletes all of its data. Otherwise decreasing the size is an error.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					defaultplanmodifier.Bool(false),
+				},
+			}),
 			"size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
 				MarkdownDescription: "The storage size of this filesystem given in GiB.",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					sizePlanModifier{resourceName: "filesystem"},
+				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
@@ -113,6 +133,31 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 					listplanmodifier.UseStateForUnknown(), // immutable
 				},
 			}),
+			"mount_cloud_init": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The `mounts` and `runcmd` keys of a cloud-config which mount the filesystem at `mount_point`. " +
+					"Use the `genesiscloud_filesystem_mount_config` data source to mount several filesystems with one `startup_script`.",
+				Computed: true,
+			}),
+			"mount_command": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The shell command which creates `mount_point` and mounts the filesystem at it. It has to be run as root.",
+				Computed:            true,
+			}),
+			"mount_fstab_entry": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The line of `/etc/fstab` which mounts the filesystem at `mount_point` on boot.",
+				Computed:            true,
+			}),
+			"mount_point": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The local directory of the instance the filesystem is mounted at by `mount_command`, `mount_fstab_entry` and `mount_cloud_init`. " +
+					"Defaults to `/mnt/` followed by the `name` at creation, with the characters which are not allowed replaced by `-`.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					mountPointValidator{},
+				},
+			}),
 			"type": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The storage type of the filesystem.",
 				Required:            true,
@@ -139,6 +184,15 @@ func (r *FilesystemResource) Schema(ctx context.Context, req resource.SchemaRequ
 	}
 }
 
+func (r *FilesystemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to protect on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(deletionProtectionResizablePlan(ctx, "filesystem", req, filesystemReplaceAttributes)...)
+}
+
 func (r *FilesystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data FilesystemResourceModel
 
@@ -287,9 +341,11 @@ func (r *FilesystemResource) Read(ctx context.Context, req resource.ReadRequest,
 
 func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data FilesystemResourceModel
+	var state FilesystemResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -325,7 +381,27 @@ func (r *FilesystemResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &filesystemResponse.Filesystem)...)
+	filesystem := &filesystemResponse.Filesystem
+
+	if size := data.Size.ValueInt64(); size > state.Size.ValueInt64() {
+		tflog.Info(ctx, "waiting for the online resize of a filesystem", map[string]interface{}{"id": filesystemId, "size": size})
+
+		// The filesystem is resized online, the new size shows up once the resize is done
+		filesystem, diag = waitForFilesystem(ctx, r.client, filesystemId, func(filesystem *genesiscloud.Filesystem) bool {
+			return int64(filesystem.Size) >= size || filesystem.Status == genesiscloud.FilesystemStatusError
+		})
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if filesystem.Status == genesiscloud.FilesystemStatusError {
+			resp.Diagnostics.AddError("Resize Error", fmt.Sprintf("The filesystem with id %q failed to resize to %d GiB.", filesystemId, size))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, filesystem)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -354,6 +430,11 @@ func (r *FilesystemResource) Delete(ctx context.Context, req resource.DeleteRequ
 
 	filesystemId := data.Id.ValueString()
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Deletion Protection", deletionProtectionError("filesystem", filesystemId))
+		return
+	}
+
 	if data.RetainOnDelete.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Filesystem is retained",
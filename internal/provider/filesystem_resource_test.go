@@ -28,6 +28,8 @@ func TestAccFilesystemResource(t *testing.T) {
 					// resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "id", "ssh-key-id"),
 					resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "name", "one"),
 					resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "size", "1"),
+					resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "mount_point", "/mnt/one"),
+					resource.TestCheckResourceAttrSet("genesiscloud_filesystem.test", "mount_command"),
 				),
 			},
 			// ImportState testing
@@ -41,6 +43,8 @@ func TestAccFilesystemResource(t *testing.T) {
 				Config: providerConfig + testAccFilesystemResourceConfig("two", 1),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "name", "two"),
+					// The default mount point is kept on rename
+					resource.TestCheckResourceAttr("genesiscloud_filesystem.test", "mount_point", "/mnt/one"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
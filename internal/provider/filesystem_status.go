@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func getFilesystem(ctx context.Context, client *Client, filesystemId string, verb string) (*genesiscloud.Filesystem, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := client.GetFilesystemWithResponse(ctx, filesystemId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return nil, diags
+	}
+
+	filesystemResponse := response.JSON200
+	if filesystemResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &filesystemResponse.Filesystem, diags
+}
+
+// waitForFilesystem polls the filesystem until done returns true.
+func waitForFilesystem(ctx context.Context, client *Client, filesystemId string, done func(filesystem *genesiscloud.Filesystem) bool) (*genesiscloud.Filesystem, diag.Diagnostics) {
+	var status genesiscloud.FilesystemStatus
+
+	for {
+		err := client.PollingWait(ctx)
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Polling Error", generateErrorMessage("polling filesystem", err))
+			return nil, diags
+		}
+
+		tflog.Trace(ctx, "polling a filesystem resource")
+
+		filesystem, diags := getFilesystem(ctx, client, filesystemId, "polling filesystem")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if filesystem.Status != status {
+			tflog.Debug(ctx, "filesystem status changed", map[string]interface{}{"id": filesystemId, "status": string(filesystem.Status)})
+			status = filesystem.Status
+		}
+
+		if done(filesystem) {
+			return filesystem, diags
+		}
+	}
+}
@@ -16,6 +16,9 @@ type FilesystemResourceModel struct {
 	// Description The human-readable description for the filesystem.
 	Description types.String `tfsdk:"description"`
 
+	// DeletionProtection Flag to protect the filesystem from being destroyed or replaced.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Id The unique ID of the filesystem.
 	Id types.String `tfsdk:"id"`
 
@@ -28,9 +31,24 @@ type FilesystemResourceModel struct {
 	// MountBasePath The base path for the filesystem mount.
 	MountBasePath types.String `tfsdk:"mount_base_path"`
 
+	// MountCloudInit The cloud-config fragment which mounts the filesystem.
+	MountCloudInit types.String `tfsdk:"mount_cloud_init"`
+
+	// MountCommand The shell command which mounts the filesystem.
+	MountCommand types.String `tfsdk:"mount_command"`
+
+	// MountFstabEntry The line of /etc/fstab which mounts the filesystem.
+	MountFstabEntry types.String `tfsdk:"mount_fstab_entry"`
+
+	// MountPoint The local directory the filesystem is mounted at.
+	MountPoint types.String `tfsdk:"mount_point"`
+
 	// Region The region identifier.
 	Region types.String `tfsdk:"region"`
 
+	// ReplaceOnShrink Flag to replace the filesystem if its size is decreased.
+	ReplaceOnShrink types.Bool `tfsdk:"replace_on_shrink"`
+
 	// Size The storage size of this filesystem given in GiB.
 	Size types.Int64 `tfsdk:"size"`
 
@@ -69,5 +87,22 @@ func (data *FilesystemResourceModel) PopulateFromClientResponse(ctx context.Cont
 	data.Status = types.StringValue(string(filesystem.Status))
 	data.Type = types.StringValue(string(filesystem.Type))
 
+	if data.MountPoint.IsNull() || data.MountPoint.IsUnknown() {
+		data.MountPoint = types.StringValue(defaultFilesystemMountPoint(filesystem.Name))
+	}
+
+	// The mount configuration is only available once the filesystem is created
+	mount, err := newFilesystemMount(filesystem, data.MountPoint.ValueString())
+	if err != nil {
+		data.MountCloudInit = types.StringNull()
+		data.MountCommand = types.StringNull()
+		data.MountFstabEntry = types.StringNull()
+		return
+	}
+
+	data.MountCloudInit = types.StringValue(filesystemMountsCloudInit([]filesystemMount{*mount}))
+	data.MountCommand = types.StringValue(mount.Command())
+	data.MountFstabEntry = types.StringValue(mount.FstabEntry())
+
 	return
 }
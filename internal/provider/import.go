@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importCandidate is a resource matching a composite import id.
+type importCandidate struct {
+	Id string
+
+	// Description identifies the resource for humans if the match is ambiguous.
+	Description string
+}
+
+// importResolver returns the resources matching the value of a composite import id.
+type importResolver func(ctx context.Context, value string) ([]importCandidate, diag.Diagnostics)
+
+// resolveImportId resolves a composite import id of the form `<prefix>:<value>`
+// with the resolver of the prefix. Ids without a known prefix are returned as is.
+func resolveImportId(ctx context.Context, importId string, resolvers map[string]importResolver) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	prefix, value, found := strings.Cut(importId, ":")
+	resolver, ok := resolvers[prefix]
+	if !found || !ok {
+		return importId, diags
+	}
+
+	candidates, diags := resolver(ctx, value)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	switch len(candidates) {
+	case 0:
+		diags.AddError("Import Error", fmt.Sprintf("There is no resource with the %s %q.", prefix, value))
+		return "", diags
+	case 1:
+		return candidates[0].Id, diags
+	}
+
+	descriptions := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		descriptions = append(descriptions, fmt.Sprintf("  - %s: %s", candidate.Id, candidate.Description))
+	}
+	sort.Strings(descriptions)
+
+	diags.AddError("Import Error", fmt.Sprintf("There are %d resources with the %s %q, import one of them by its id:\n%s",
+		len(candidates), prefix, value, strings.Join(descriptions, "\n")))
+	return "", diags
+}
+
+// importStateComposite imports a resource by its id or by a composite import
+// id of the form `<prefix>:<value>`, e.g. `name:example`.
+func importStateComposite(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, resolvers map[string]importResolver) {
+	id, diags := resolveImportId(ctx, req.ID, resolvers)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestResolveImportId(t *testing.T) {
+	resources := []importCandidate{
+		{Id: "id-1", Description: "one"},
+		{Id: "id-2", Description: "duplicate"},
+		{Id: "id-3", Description: "duplicate"},
+	}
+
+	resolvers := map[string]importResolver{
+		"name": func(ctx context.Context, value string) ([]importCandidate, diag.Diagnostics) {
+			var candidates []importCandidate
+			for _, resource := range resources {
+				if resource.Description == value {
+					candidates = append(candidates, resource)
+				}
+			}
+			return candidates, nil
+		},
+	}
+
+	testCases := map[string]struct {
+		importId string
+		id       string
+		contains []string
+	}{
+		"id":                 {importId: "id-1", id: "id-1"},
+		"unknown prefix":     {importId: "other:one", id: "other:one"},
+		"match":              {importId: "name:one", id: "id-1"},
+		"no match":           {importId: "name:two", contains: []string{`"two"`}},
+		"ambiguous match":    {importId: "name:duplicate", contains: []string{"id-2", "id-3"}},
+		"value with a colon": {importId: "name:one:two", contains: []string{`"one:two"`}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			id, diags := resolveImportId(context.Background(), testCase.importId, resolvers)
+
+			if testCase.contains == nil {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				if id != testCase.id {
+					t.Errorf("expected %q, got %q", testCase.id, id)
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("expected an error, got %q", id)
+			}
+
+			detail := diags.Errors()[0].Detail()
+			for _, expected := range testCase.contains {
+				if !strings.Contains(detail, expected) {
+					t.Errorf("expected the error to contain %s, got %s", expected, detail)
+				}
+			}
+		})
+	}
+}
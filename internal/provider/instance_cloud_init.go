@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// defaultCloudInitUser is the user connected as if none is configured.
+	defaultCloudInitUser = "ubuntu"
+
+	// defaultCloudInitTimeout is the time waited for cloud-init if no timeout is configured.
+	defaultCloudInitTimeout = "30m"
+
+	// cloudInitOutputLog is the log of the cloud-init modules including the startup script.
+	cloudInitOutputLog = "/var/log/cloud-init-output.log"
+
+	// cloudInitOutputLogLines is the number of lines of the log included in errors.
+	cloudInitOutputLogLines = 30
+
+	// cloudInitExitRecoverable is the exit code of `cloud-init status` if it is done with recoverable errors.
+	cloudInitExitRecoverable = 2
+)
+
+var errCloudInitFailed = errors.New("cloud-init failed")
+
+// cloudInitWait configures how waitForCloudInit connects to the instance and what it waits for.
+type cloudInitWait struct {
+	Address    string
+	Port       int
+	User       string
+	PrivateKey string
+
+	// SentinelFile is waited for instead of `cloud-init status --wait` if set.
+	SentinelFile string
+
+	// Interval is the time between connection attempts and sentinel file checks.
+	Interval time.Duration
+}
+
+// waitForCloudInit connects to the instance via SSH and waits until cloud-init
+// is done or the sentinel file exists. If cloud-init fails, the returned error
+// includes the tail of the cloud-init output log.
+func waitForCloudInit(ctx context.Context, wait cloudInitWait) error {
+	signer, err := ssh.ParsePrivateKey([]byte(wait.PrivateKey))
+	if err != nil {
+		return fmt.Errorf("parsing private key: %w", err)
+	}
+
+	config := &ssh.ClientConfig{
+		User: wait.User,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+
+		// The host key of a new instance is not known in advance
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+
+		Timeout: waitForDialTimeout,
+	}
+
+	target := net.JoinHostPort(wait.Address, strconv.Itoa(wait.Port))
+
+	for attempt := 1; ; attempt++ {
+		done, err := checkCloudInit(ctx, target, config, wait.SentinelFile)
+		if err != nil && errors.Is(err, errCloudInitFailed) {
+			return err
+		}
+		if err == nil && done {
+			return nil
+		}
+
+		fields := map[string]interface{}{"address": target, "attempt": attempt}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		tflog.Trace(ctx, "cloud-init is not done yet", fields)
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("waiting for cloud-init on %s: %w (last error: %s)", target, ctx.Err(), err)
+			}
+			return fmt.Errorf("waiting for cloud-init on %s: %w", target, ctx.Err())
+		case <-time.After(wait.Interval):
+		}
+	}
+}
+
+// checkCloudInit connects once and reports whether cloud-init is done. Errors
+// wrapping errCloudInitFailed are final, all others are retried.
+func checkCloudInit(ctx context.Context, target string, config *ssh.ClientConfig, sentinelFile string) (bool, error) {
+	client, err := dialSSH(ctx, target, config)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	// Abort the commands if ctx is done
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	if sentinelFile != "" {
+		_, exitStatus, err := runSSHCommand(client, "test -f "+shellQuote(sentinelFile))
+		if err != nil {
+			return false, err
+		}
+
+		return exitStatus == 0, nil
+	}
+
+	output, exitStatus, err := runSSHCommand(client, "cloud-init status --wait")
+	if err != nil {
+		return false, err
+	}
+
+	switch exitStatus {
+	case 0:
+		return true, nil
+	case cloudInitExitRecoverable:
+		tflog.Warn(ctx, "cloud-init is done with recoverable errors", map[string]interface{}{"output": output})
+		return true, nil
+	}
+
+	logTail, _, err := runSSHCommand(client, fmt.Sprintf("tail -n %d %s", cloudInitOutputLogLines, cloudInitOutputLog))
+	if err != nil {
+		logTail = fmt.Sprintf("(reading %s failed: %s)", cloudInitOutputLog, err)
+	}
+
+	return false, fmt.Errorf("%w with exit status %d: %s\n\nLast lines of %s:\n%s",
+		errCloudInitFailed, exitStatus, strings.TrimSpace(output), cloudInitOutputLog, strings.TrimRight(logTail, "\n"))
+}
+
+// dialSSH connects to the target, respecting the cancellation of ctx.
+func dialSSH(ctx context.Context, target string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	dialer := net.Dialer{Timeout: config.Timeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return nil, err
+	}
+
+	// Abort the handshake if ctx is done
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	sshConn, channels, requests, err := ssh.NewClientConn(conn, target, config)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return ssh.NewClient(sshConn, channels, requests), nil
+}
+
+// runSSHCommand runs the command and returns its combined output and exit status.
+func runSSHCommand(client *ssh.Client, command string) (string, int, error) {
+	session, err := client.NewSession()
+	if err != nil {
+		return "", 0, err
+	}
+	defer session.Close()
+
+	output, err := session.CombinedOutput(command)
+
+	var exitError *ssh.ExitError
+	if errors.As(err, &exitError) {
+		return string(output), exitError.ExitStatus(), nil
+	}
+
+	return string(output), 0, err
+}
+
+// shellQuote quotes the value for a POSIX shell.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
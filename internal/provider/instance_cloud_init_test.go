@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// sshCommandHandler returns the output and exit status of a command run on the fake SSH server.
+type sshCommandHandler func(command string) (string, uint32)
+
+// newTestSSHKey generates an ed25519 key and returns the signer and the private key in OpenSSH format.
+func newTestSSHKey(t *testing.T) (ssh.Signer, string) {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatalf("creating signer: %s", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatalf("marshaling key: %s", err)
+	}
+
+	return signer, string(pem.EncodeToMemory(block))
+}
+
+// listenSSH starts an SSH server on 127.0.0.1 which accepts the client key and
+// runs exec requests with the handler.
+func listenSSH(t *testing.T, clientKey ssh.PublicKey, handler sshCommandHandler) int {
+	t.Helper()
+
+	hostKey, _ := newTestSSHKey(t)
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() != defaultCloudInitUser || !bytes.Equal(key.Marshal(), clientKey.Marshal()) {
+				return nil, errors.New("unauthorized")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveSSH(conn, config, handler)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func serveSSH(conn net.Conn, config *ssh.ServerConfig, handler sshCommandHandler) {
+	defer conn.Close()
+
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer channel.Close()
+
+			for request := range channelRequests {
+				if request.Type != "exec" {
+					_ = request.Reply(false, nil)
+					continue
+				}
+				_ = request.Reply(true, nil)
+
+				// The payload is the command as an SSH string
+				command := string(request.Payload[4:])
+				output, exitStatus := handler(command)
+
+				_, _ = channel.Write([]byte(output))
+
+				status := make([]byte, 4)
+				binary.BigEndian.PutUint32(status, exitStatus)
+				_, _ = channel.SendRequest("exit-status", false, status)
+				return
+			}
+		}()
+	}
+}
+
+func TestWaitForCloudInit(t *testing.T) {
+	testCases := map[string]struct {
+		sentinelFile string
+		handler      sshCommandHandler
+		done         bool
+		failed       bool
+		contains     string
+	}{
+		"done": {
+			handler: func(command string) (string, uint32) {
+				return "status: done\n", 0
+			},
+			done: true,
+		},
+		"recoverable error": {
+			handler: func(command string) (string, uint32) {
+				return "status: done\n", cloudInitExitRecoverable
+			},
+			done: true,
+		},
+		"error": {
+			handler: func(command string) (string, uint32) {
+				if strings.HasPrefix(command, "tail ") {
+					return "running startup script\nstartup script failed\n", 0
+				}
+				return "status: error\n", 1
+			},
+			failed:   true,
+			contains: "startup script failed",
+		},
+		"sentinel file exists": {
+			sentinelFile: "/var/lib/done",
+			handler: func(command string) (string, uint32) {
+				if command != "test -f '/var/lib/done'" {
+					return "", 127
+				}
+				return "", 0
+			},
+			done: true,
+		},
+		"sentinel file missing": {
+			sentinelFile: "/var/lib/done",
+			handler: func(command string) (string, uint32) {
+				return "", 1
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			signer, privateKey := newTestSSHKey(t)
+			port := listenSSH(t, signer.PublicKey(), testCase.handler)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			err := waitForCloudInit(ctx, cloudInitWait{
+				Address:      "127.0.0.1",
+				Port:         port,
+				User:         defaultCloudInitUser,
+				PrivateKey:   privateKey,
+				SentinelFile: testCase.sentinelFile,
+				Interval:     10 * time.Millisecond,
+			})
+
+			switch {
+			case testCase.done:
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			case testCase.failed:
+				if !errors.Is(err, errCloudInitFailed) {
+					t.Fatalf("expected a cloud-init error, got %v", err)
+				}
+				if !strings.Contains(err.Error(), testCase.contains) {
+					t.Errorf("expected the error to contain %q, got %s", testCase.contains, err)
+				}
+			default:
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("expected a deadline error, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForCloudInitUnauthorized(t *testing.T) {
+	signer, _ := newTestSSHKey(t)
+	_, otherPrivateKey := newTestSSHKey(t)
+
+	port := listenSSH(t, signer.PublicKey(), func(command string) (string, uint32) {
+		return "", 0
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	err := waitForCloudInit(ctx, cloudInitWait{
+		Address:    "127.0.0.1",
+		Port:       port,
+		User:       defaultCloudInitUser,
+		PrivateKey: otherPrivateKey,
+		Interval:   10 * time.Millisecond,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+}
+
+func TestWaitForCloudInitInvalidKey(t *testing.T) {
+	err := waitForCloudInit(context.Background(), cloudInitWait{
+		Address:    "127.0.0.1",
+		Port:       unusedPort(t),
+		PrivateKey: "not a key",
+	})
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestDialSSHStopsAbortAfterHandshake(t *testing.T) {
+	signer, _ := newTestSSHKey(t)
+
+	port := listenSSH(t, signer.PublicKey(), func(command string) (string, uint32) {
+		return "ok", 0
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client, err := dialSSH(ctx, net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), &ssh.ClientConfig{
+		User:            defaultCloudInitUser,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer client.Close()
+
+	// The connection is owned by the client after the handshake
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	output, exitStatus, err := runSSHCommand(client, "true")
+	if err != nil || exitStatus != 0 || output != "ok" {
+		t.Errorf("expected the command to succeed after the cancellation, got %q, %d, %v", output, exitStatus, err)
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// validateInstanceDiskResize returns an error if the disk cannot be resized
+// from the current to the target size, i.e. if it would shrink.
+func validateInstanceDiskResize(current, target int64) error {
+	if target < current {
+		return fmt.Errorf("cannot decrease the disk size from %d GiB to %d GiB, the disk can only grow", current, target)
+	}
+
+	return nil
+}
+
+// updateInstanceDiskSize requests the resize of the disk without waiting for it.
+func updateInstanceDiskSize(ctx context.Context, client *Client, instanceId string, diskSize int64) (diags diag.Diagnostics) {
+	body := genesiscloud.UpdateInstanceJSONRequestBody{}
+	body.DiskSize = pointer(int(diskSize))
+
+	response, err := client.UpdateInstanceWithResponse(ctx, instanceId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("resize instance disk", err))
+		return
+	}
+
+	if response.JSON200 == nil {
+		diags.AddError("Client Error", generateClientErrorMessage("resize instance disk", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+	}
+
+	return
+}
+
+// resizeInstanceDisk grows the disk of the instance and waits until the new
+// size is reported. If stop is set, a running instance is stopped for the
+// resize and started again afterwards.
+func resizeInstanceDisk(ctx context.Context, client *Client, instanceId string, diskSize int64, stop bool) (*genesiscloud.Instance, diag.Diagnostics) {
+	instance, diags := waitForStableInstance(ctx, client, instanceId)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	priorStatus := instance.Status
+
+	tflog.Info(ctx, "resizing the disk of an instance", map[string]interface{}{"id": instanceId, "disk_size": diskSize, "status": string(priorStatus)})
+
+	stopped := false
+
+	// Only a running instance is stopped for the resize
+	if stop && priorStatus == genesiscloud.InstanceStatusActive {
+		tflog.Info(ctx, "stopping the instance to resize its disk", map[string]interface{}{"id": instanceId})
+
+		_, diags = transitionInstanceStatus(ctx, client, instanceId, genesiscloud.InstanceStatusStopped)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		stopped = true
+	}
+
+	diags = updateInstanceDiskSize(ctx, client, instanceId, diskSize)
+
+	if !diags.HasError() {
+		tflog.Info(ctx, "waiting for the disk resize of an instance", map[string]interface{}{"id": instanceId, "disk_size": diskSize})
+
+		instance, diags = waitForInstance(ctx, client, instanceId, func(instance *genesiscloud.Instance) bool {
+			if instance.Status == genesiscloud.InstanceStatusError {
+				return true
+			}
+
+			return instance.DiskSize != nil && int64(*instance.DiskSize) >= diskSize && !isTransientInstanceStatus(instance.Status)
+		})
+
+		if !diags.HasError() && instance.Status == genesiscloud.InstanceStatusError {
+			diags.AddError("Resize Error", fmt.Sprintf("The instance with id %q is in error status after resizing its disk to %d GiB.", instanceId, diskSize))
+		}
+	}
+
+	// The prior power state is restored even if the resize failed
+	if stopped {
+		tflog.Info(ctx, "starting the instance again after resizing its disk", map[string]interface{}{"id": instanceId})
+
+		started, startDiags := transitionInstanceStatus(ctx, client, instanceId, priorStatus)
+		diags.Append(startDiags...)
+		if !startDiags.HasError() {
+			instance = started
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	tflog.Info(ctx, "resized the disk of an instance", map[string]interface{}{"id": instanceId, "disk_size": diskSize})
+
+	return instance, diags
+}
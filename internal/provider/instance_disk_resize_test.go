@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/genesiscloud/genesiscloud-go"
+)
+
+func TestValidateInstanceDiskResize(t *testing.T) {
+	testCases := map[string]struct {
+		current, target int64
+		valid           bool
+	}{
+		"grow":      {current: 80, target: 100, valid: true},
+		"unchanged": {current: 80, target: 80, valid: true},
+		"shrink":    {current: 80, target: 50, valid: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateInstanceDiskResize(testCase.current, testCase.target)
+			if testCase.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestResizeInstanceDisk(t *testing.T) {
+	testCases := map[string]struct {
+		status             genesiscloud.InstanceStatus
+		stop               bool
+		resizeRequiresStop bool
+		resizeErrorCode    string
+		actions            []genesiscloud.InstanceAction
+		err                bool
+	}{
+		"active online": {
+			status: genesiscloud.InstanceStatusActive,
+		},
+		"active stop": {
+			status:             genesiscloud.InstanceStatusActive,
+			stop:               true,
+			resizeRequiresStop: true,
+			actions:            []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop, genesiscloud.InstanceActionStart},
+		},
+		"active requires stop": {
+			status:             genesiscloud.InstanceStatusActive,
+			resizeRequiresStop: true,
+			err:                true,
+		},
+		"stopped stop": {
+			status:             genesiscloud.InstanceStatusStopped,
+			stop:               true,
+			resizeRequiresStop: true,
+		},
+		"starting stop": {
+			status:             genesiscloud.InstanceStatusStarting,
+			stop:               true,
+			resizeRequiresStop: true,
+			actions:            []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop, genesiscloud.InstanceActionStart},
+		},
+		"active stop error": {
+			status:          genesiscloud.InstanceStatusActive,
+			stop:            true,
+			resizeErrorCode: "quota_exceeded",
+			actions:         []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop, genesiscloud.InstanceActionStart},
+			err:             true,
+		},
+		"active error": {
+			status:          genesiscloud.InstanceStatusActive,
+			resizeErrorCode: "quota_exceeded",
+			err:             true,
+		},
+		"error stop": {
+			status:             genesiscloud.InstanceStatusError,
+			stop:               true,
+			resizeRequiresStop: true,
+			err:                true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, api := newFakeInstanceClient(t, testCase.status)
+			api.diskSize = 80
+			api.resizeRequiresStop = testCase.resizeRequiresStop
+			api.resizeErrorCode = testCase.resizeErrorCode
+
+			instance, diags := resizeInstanceDisk(context.Background(), client, fakeInstanceId, 100, testCase.stop)
+
+			if strings.Join(instanceActionStrings(api.actions), ",") != strings.Join(instanceActionStrings(testCase.actions), ",") {
+				t.Errorf("expected actions %v, got %v", testCase.actions, api.actions)
+			}
+
+			if testCase.err {
+				if !diags.HasError() {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if instance.DiskSize == nil || *instance.DiskSize != 100 {
+				t.Errorf("expected disk size 100, got %v", instance.DiskSize)
+			}
+
+			if isTransientInstanceStatus(testCase.status) {
+				return
+			}
+
+			if instance.Status != testCase.status {
+				t.Errorf("expected status %q to be restored, got %q", testCase.status, instance.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"slices"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultCapacityErrorCodes are the default of `fallback_error_codes`, the API
+// error codes which mean that an instance type is not available right now, so
+// another type or region may succeed. The API reference does not list the error
+// codes of a failed creation, which is why they can be configured. They are
+// matched exactly, as rate limits or image and region errors must not cause a
+// different type or region to be created.
+var defaultCapacityErrorCodes = []string{
+	"insufficient_capacity",
+	"instance_type_sold_out",
+	"out_of_capacity",
+	"quota_exceeded",
+}
+
+// isCapacityError reports whether the API error has one of the codes of missing capacity or an exhausted quota.
+func isCapacityError(err *genesiscloud.Error, codes []string) bool {
+	return err != nil && slices.Contains(codes, err.Code)
+}
+
+// instanceCandidate is a combination of region and instance type an instance can be created with.
+type instanceCandidate struct {
+	Region genesiscloud.Region
+	Type   genesiscloud.InstanceType
+}
+
+// instanceCandidates returns the combinations of region and instance type in
+// the order they are tried. All types are tried in a region before the next
+// region is tried. Duplicates are skipped.
+func instanceCandidates(region string, regionFallbacks []string, instanceType string, typeFallbacks []string) []instanceCandidate {
+	regions := uniqueStrings(append([]string{region}, regionFallbacks...))
+	instanceTypes := uniqueStrings(append([]string{instanceType}, typeFallbacks...))
+
+	candidates := make([]instanceCandidate, 0, len(regions)*len(instanceTypes))
+	for _, region := range regions {
+		for _, instanceType := range instanceTypes {
+			candidates = append(candidates, instanceCandidate{
+				Region: genesiscloud.Region(region),
+				Type:   genesiscloud.InstanceType(instanceType),
+			})
+		}
+	}
+
+	return candidates
+}
+
+// preferredValue returns the configured value if the actual value is the
+// configured value or one of its fallbacks, so choosing a fallback does not
+// cause a diff. Otherwise the actual value is returned.
+func preferredValue(configured types.String, fallbacks []string, actual string) types.String {
+	if configured.IsNull() || configured.IsUnknown() {
+		return types.StringValue(actual)
+	}
+
+	if configured.ValueString() == actual {
+		return configured
+	}
+
+	for _, fallback := range fallbacks {
+		if fallback == actual {
+			return configured
+		}
+	}
+
+	return types.StringValue(actual)
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if seen[value] {
+			continue
+		}
+
+		seen[value] = true
+		result = append(result, value)
+	}
+
+	return result
+}
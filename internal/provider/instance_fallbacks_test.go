@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIsCapacityError(t *testing.T) {
+	testCases := map[string]bool{
+		"insufficient_capacity":   true,
+		"out_of_capacity":         true,
+		"quota_exceeded":          true,
+		"instance_type_sold_out":  true,
+		"rate_limit_exceeded":     false,
+		"request_limit_exceeded":  false,
+		"image_not_available":     false,
+		"region_unavailable":      false,
+		"resource_unavailable":    false,
+		"capacity_check_disabled": false,
+		"invalid_request":         false,
+		"not_found":               false,
+		"unauthorized":            false,
+		"":                        false,
+	}
+
+	for code, expected := range testCases {
+		t.Run(code, func(t *testing.T) {
+			if actual := isCapacityError(&genesiscloud.Error{Code: code}, defaultCapacityErrorCodes); actual != expected {
+				t.Errorf("expected %t, got %t", expected, actual)
+			}
+		})
+	}
+
+	if isCapacityError(nil, defaultCapacityErrorCodes) {
+		t.Errorf("expected no capacity error without an error")
+	}
+
+	if !isCapacityError(&genesiscloud.Error{Code: "resource_unavailable"}, []string{"resource_unavailable"}) {
+		t.Errorf("expected a capacity error with a configured code")
+	}
+
+	if isCapacityError(&genesiscloud.Error{Code: "quota_exceeded"}, []string{}) {
+		t.Errorf("expected no capacity error without codes")
+	}
+}
+
+func TestInstanceCandidates(t *testing.T) {
+	candidates := instanceCandidates("A", []string{"B", "A"}, "x", []string{"y", "x", "z"})
+
+	expected := []instanceCandidate{
+		{Region: "A", Type: "x"},
+		{Region: "A", Type: "y"},
+		{Region: "A", Type: "z"},
+		{Region: "B", Type: "x"},
+		{Region: "B", Type: "y"},
+		{Region: "B", Type: "z"},
+	}
+
+	if !reflect.DeepEqual(candidates, expected) {
+		t.Errorf("expected %v, got %v", expected, candidates)
+	}
+
+	candidates = instanceCandidates("A", nil, "x", nil)
+	if !reflect.DeepEqual(candidates, []instanceCandidate{{Region: "A", Type: "x"}}) {
+		t.Errorf("expected only the configured candidate, got %v", candidates)
+	}
+}
+
+func TestPreferredValue(t *testing.T) {
+	testCases := map[string]struct {
+		configured types.String
+		fallbacks  []string
+		actual     string
+		expected   types.String
+	}{
+		"configured": {
+			configured: types.StringValue("x"),
+			fallbacks:  []string{"y"},
+			actual:     "x",
+			expected:   types.StringValue("x"),
+		},
+		"fallback": {
+			configured: types.StringValue("x"),
+			fallbacks:  []string{"y"},
+			actual:     "y",
+			expected:   types.StringValue("x"),
+		},
+		"drift": {
+			configured: types.StringValue("x"),
+			fallbacks:  []string{"y"},
+			actual:     "z",
+			expected:   types.StringValue("z"),
+		},
+		"import": {
+			configured: types.StringNull(),
+			actual:     "y",
+			expected:   types.StringValue("y"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := preferredValue(testCase.configured, testCase.fallbacks, testCase.actual)
+			if !actual.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -2,12 +2,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,8 +32,22 @@ var (
 	_ resource.ResourceWithConfigure        = &InstanceResource{}
 	_ resource.ResourceWithImportState      = &InstanceResource{}
 	_ resource.ResourceWithConfigValidators = &InstanceResource{}
+	_ resource.ResourceWithModifyPlan       = &InstanceResource{}
 )
 
+// instanceReplaceAttributes are the attributes whose change replaces the instance,
+// which the deletion protection prevents. They have to match the schema.
+var instanceReplaceAttributes = []path.Path{
+	path.Root("hostname"),
+	path.Root("image"),
+	path.Root("metadata").AtName("startup_script"),
+	path.Root("password"),
+	path.Root("placement_option"),
+	path.Root("region"),
+	path.Root("ssh_key_ids"),
+	path.Root("type"),
+}
+
 func NewInstanceResource() resource.Resource {
 	return &InstanceResource{}
 }
@@ -86,11 +107,26 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			}),
+			"deletion_protection": deletionProtectionAttribute(ctx, "instance"),
 			"disk_size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
-				MarkdownDescription: "The disk size of the instance in GB.",
+				MarkdownDescription: "The disk size of the instance in GB. The disk can only grow. Set `stop_for_disk_resize` if the disk of a running instance can only be resized while it is stopped.",
 				Optional:            true,
 				Computed:            true,
 			}),
+			"effective_region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The region the instance was created in, which is `region` or one of `region_fallbacks`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"effective_type": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The instance type the instance was created with, which is `type` or one of `type_fallbacks`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
 			"image_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The resulting image ID of the instance.",
 				Computed:            true,
@@ -117,6 +153,31 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "The human-readable name for the instance.",
 				Required:            true,
 			}),
+			"fallback_error_codes": resourceenhancer.Attribute(ctx, schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The API error codes of a failed creation on which the next of `region_fallbacks` and `type_fallbacks` is tried. " +
+					"Defaults to `insufficient_capacity`, `instance_type_sold_out`, `out_of_capacity` and `quota_exceeded`.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			}),
+			"final_snapshot": schema.SingleNestedAttribute{
+				MarkdownDescription: "Option to create a snapshot of the instance before it is destroyed. " +
+					"The instance is only deleted once the snapshot is created, so the `delete` timeout has to cover the snapshot creation. " +
+					"The id of the snapshot is reported in a warning.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"name_template": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("The name of the snapshot. The placeholders `{name}`, `{id}` and `{timestamp}` are replaced "+
+							"with the instance name, the instance id and the UTC time of the deletion. Defaults to `%s`.", defaultFinalSnapshotNameTemplate),
+						Optional: true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					}),
+				},
+			},
 			"floating_ip_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The floating IP attached to the instance.",
 				Optional:            true,
@@ -145,6 +206,19 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 				},
 				Validators: []validator.String{},
 			}),
+			"power_state": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The target power state of the instance. The instance is started or stopped accordingly " +
+					"and a new instance is stopped after provisioning if set to `stopped`. " +
+					"If not provided, the power state is not managed, e.g. by a `genesiscloud_instance_status` resource. " +
+					"While the instance is in another status, e.g. `error` or `starting`, the power state is not compared.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						string(genesiscloud.InstanceStatusActive),
+						string(genesiscloud.InstanceStatusStopped),
+					),
+				},
+			}),
 			"private_ip": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The private IPv4 IP-Address (IPv4 address).",
 				Computed:            true,
@@ -153,6 +227,29 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					// TODO: Could be changed outside of terraform via stop+start?
 				},
 			}),
+			"provisioning_retries": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "How often an instance which ends up in error state during provisioning, e.g. due to transient capacity issues, " +
+					"is deleted and created again before giving up. The `create` timeout has to cover all attempts.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					defaultplanmodifier.Int64(0),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			}),
+			"provisioning_retry_delay": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The time to wait after an instance in error state is deleted before it is created again.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(defaultProvisioningRetryDelay),
+				},
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			}),
 			"public_ip": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The public IPv4 IP-Address (IPv4 address).",
 				Computed:            true,
@@ -171,6 +268,18 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
 				},
 			}),
+			"region_fallbacks": resourceenhancer.Attribute(ctx, schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The regions tried in order if the instance cannot be created in `region` due to missing capacity or an exhausted quota. " +
+					"All types are tried in a region before the next region is tried. " +
+					"Only suitable for region-agnostic workloads, e.g. without volumes, security groups or a floating IP. " +
+					"The chosen region is reported as `effective_region`.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...)),
+				},
+			}),
 			"security_group_ids": resourceenhancer.Attribute(ctx, schema.SetAttribute{
 				ElementType:         types.StringType,
 				MarkdownDescription: "The security groups of the instance. If not provided will be set to the default security group.",
@@ -197,6 +306,15 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.UseStateForUnknown(), // immutable
 				},
 			}),
+			"stop_for_disk_resize": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to stop a running instance to resize its disk and start it again afterwards. " +
+					"Otherwise the disk of a running instance is resized while it is running.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					defaultplanmodifier.Bool(false),
+				},
+			}),
 			"type": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The instance type identifier. Learn more about instance types [here](https://developers.genesiscloud.com/instances#instance-types).",
 				Required:            true,
@@ -204,6 +322,15 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 					stringplanmodifier.RequiresReplace(),
 				},
 			}),
+			"type_fallbacks": resourceenhancer.Attribute(ctx, schema.ListAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "The instance types tried in order if the instance cannot be created with `type` due to missing capacity or an exhausted quota. " +
+					"The chosen type is reported as `effective_type`.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+				},
+			}),
 			"updated_at": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The timestamp when this image was last updated in RFC 3339.",
 				Computed:            true,
@@ -219,6 +346,79 @@ func (r *InstanceResource) Schema(ctx context.Context, req resource.SchemaReques
 
 				// TODO: Update of this field does not work in pulumi
 			}),
+			"wait_for": schema.SingleNestedAttribute{
+				MarkdownDescription: "Option to wait until a TCP port of the instance is reachable after it is created, " +
+					"as an `active` instance might not accept connections yet. " +
+					"The `public_ip` is probed if the instance has one, the `private_ip` otherwise.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"port": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("The TCP port which has to be reachable. Defaults to `%d`.", defaultWaitForPort),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					}),
+					"ssh_banner": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+						MarkdownDescription: "Flag to also wait for the server on the port to send an SSH banner, e.g. `SSH-2.0-OpenSSH_9.6`.",
+						Optional:            true,
+					}),
+					"timeout": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("The time the port is probed for before the creation fails. Defaults to `%s`.", defaultWaitForTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					}),
+				},
+			},
+			"wait_for_cloud_init": schema.SingleNestedAttribute{
+				MarkdownDescription: "Option to wait until cloud-init, including the `startup_script`, is done after the instance is created. " +
+					"The provider connects via SSH and runs `cloud-init status --wait`, or waits for the `sentinel_file` to exist. " +
+					"The creation fails if cloud-init reports an error, including the last lines of `/var/log/cloud-init-output.log` in the error. " +
+					"The `public_ip` is used if the instance has one, the `private_ip` otherwise.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"port": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("The SSH port. Defaults to `%d`.", defaultWaitForPort),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 65535),
+						},
+					}),
+					"private_key": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "The private key of one of the `ssh_key_ids` in OpenSSH or PEM format. Keys protected by a passphrase are not supported. " +
+							"It is write-only and never stored in the plan or state, so it can also be an ephemeral value. Requires Terraform 1.11 or later.",
+						Required:  true,
+						Sensitive: true,
+						WriteOnly: true,
+					}),
+					"sentinel_file": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: "The path of a file, e.g. created at the end of the `startup_script`, whose existence is waited for instead of running `cloud-init status --wait`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					}),
+					"timeout": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("The time waited for cloud-init before the creation fails. Defaults to `%s`.", defaultCloudInitTimeout),
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					}),
+					"user": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("The user to connect as. Defaults to `%s`.", defaultCloudInitUser),
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					}),
+				},
+				Validators: []validator.Object{
+					objectvalidator.AlsoRequires(path.MatchRoot("ssh_key_ids")),
+				},
+			},
 			"reservation_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The id of the reservation the instance is associated with.",
 				Optional:            true,
@@ -239,6 +439,80 @@ func (r *InstanceResource) ConfigValidators(ctx context.Context) []resource.Conf
 	}
 }
 
+func (r *InstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to protect or transition on create
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state InstanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		if state.DeletionProtection.ValueBool() {
+			resp.Diagnostics.Append(deletionProtectionPlanDiagnostics("instance", true, nil)...)
+		}
+		return
+	}
+
+	var plan InstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.PowerState.IsNull() && !plan.PowerState.IsUnknown() && !state.Status.IsNull() {
+		current := genesiscloud.InstanceStatus(state.Status.ValueString())
+		target := genesiscloud.InstanceStatus(plan.PowerState.ValueString())
+
+		// Other statuses, e.g. `error` or a transient one, are not a power state to compare with.
+		// They are waited out or rejected on apply, if the configured power state changed.
+		changed := !plan.PowerState.Equal(state.PowerState)
+		if isInstancePowerState(current) {
+			changed = current != target
+
+			err := validateInstanceStatusTransition(current, target)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("power_state"), "Cannot transition instance status",
+					fmt.Sprintf("The instance resource with id %q %s.", state.Id.ValueString(), err))
+				return
+			}
+		}
+
+		if changed {
+			// The status is known to be the power state after the transition
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("status"), plan.PowerState)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
+	if !plan.DiskSize.IsNull() && !plan.DiskSize.IsUnknown() && !state.DiskSize.IsNull() {
+		err := validateInstanceDiskResize(state.DiskSize.ValueInt64(), plan.DiskSize.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("disk_size"), "Cannot Resize Disk",
+				fmt.Sprintf("The instance resource with id %q %s.", state.Id.ValueString(), err))
+			return
+		}
+	}
+
+	if !state.DeletionProtection.ValueBool() {
+		return
+	}
+
+	changes, diags := deletionProtectionChanges(ctx, req.Plan, req.State, instanceReplaceAttributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deletionProtectionPlanDiagnostics("instance", false, changes)...)
+}
+
 func (r *InstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data InstanceResourceModel
 
@@ -318,79 +592,127 @@ func (r *InstanceResource) Create(ctx context.Context, req resource.CreateReques
 		body.PlacementOption = pointer(data.PlacementOption.ValueString())
 	}
 
-	response, err := r.client.CreateInstanceWithResponse(ctx, body)
+	retries := data.ProvisioningRetries.ValueInt64()
+
+	retryDelay, err := time.ParseDuration(data.ProvisioningRetryDelay.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("create instance", err))
+		resp.Diagnostics.AddError("Invalid Provisioning Retry Delay", generateErrorMessage("create instance", err))
 		return
 	}
 
-	instanceResponse := response.JSON201
-	if instanceResponse == nil {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("create instance", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
-		return
-	}
+	for attempt := int64(1); ; attempt++ {
+		instance, diag := r.createInstance(ctx, &data, body)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
+		}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	tflog.Trace(ctx, "created a instance resource")
+		tflog.Trace(ctx, "created a instance resource")
 
-	// Save data into Terraform state
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+		// Save data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 
-	instanceId := instanceResponse.Instance.Id
+		instanceId := instance.Id
 
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance", err))
+		instance, statuses, diag := r.waitForProvisioning(ctx, instanceId)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
 			return
 		}
 
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance", err))
+		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
+		// Save data into Terraform state
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		status := instanceResponse.Instance.Status
-		if status == genesiscloud.InstanceStatusActive || status == genesiscloud.InstanceStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
+		if instance.Status != genesiscloud.InstanceStatusError {
+			break
+		}
 
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
+		details := instanceProvisioningDetails(instance, statuses)
+
+		tflog.Warn(ctx, "instance provisioning attempt failed", map[string]interface{}{
+			"attempt":     attempt,
+			"attempts":    retries + 1,
+			"instance_id": instanceId,
+			"status":      string(instance.Status),
+			"statuses":    instanceStatusStrings(statuses),
+			"region":      string(instance.Region),
+			"type":        string(instance.Type),
+			"updated_at":  instance.UpdatedAt.Format(time.RFC3339),
+			"error":       ErrResourceInErrorState.Error(),
+		})
+
+		if attempt > retries {
+			resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling instance",
+				fmt.Errorf("%w after %d attempt(s), the last %s", ErrResourceInErrorState, attempt, details)))
+			return
+		}
 
-			if status == genesiscloud.InstanceStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling instance", ErrResourceInErrorState))
-			}
+		resp.Diagnostics.Append(r.deleteInstance(ctx, instanceId)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// The errored instance is gone, so a failing retry must not leave it in state
+		resp.State.RemoveResource(ctx)
+
+		tflog.Info(ctx, "retrying instance provisioning", map[string]interface{}{
+			"attempt": attempt + 1,
+			"delay":   retryDelay.String(),
+		})
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("waiting for provisioning retry", ctx.Err()))
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+
+	if data.WaitFor != nil {
+		resp.Diagnostics.Append(r.waitForReachable(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if data.WaitForCloudInit != nil {
+		// The private key is write-only, so it is only part of the configuration.
+		// It is kept out of the model, so it cannot end up in the state.
+		var privateKey types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wait_for_cloud_init").AtName("private_key"), &privateKey)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.waitForCloudInit(ctx, &data, privateKey.ValueString())...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 	}
+
+	resp.Diagnostics.Append(r.transitionPowerState(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -432,6 +754,12 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	// Report the actual power state, so the next plan corrects a drift.
+	// Other statuses keep the prior power state, as they cannot be planned.
+	if !data.PowerState.IsNull() && isInstancePowerState(genesiscloud.InstanceStatus(data.Status.ValueString())) {
+		data.PowerState = data.Status
+	}
+
 	tflog.Trace(ctx, "read a instance resource")
 
 	// Save updated data into Terraform state
@@ -440,9 +768,11 @@ func (r *InstanceResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data InstanceResourceModel
+	var state InstanceResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -482,9 +812,15 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 		}
 	}
 
-	if !data.DiskSize.IsNull() && !data.DiskSize.IsUnknown() {
-		diskSize := pointer(int(data.DiskSize.ValueInt64()))
-		body.DiskSize = diskSize
+	// A changed disk size is resized in its own workflow after the update
+	resizeDisk := !data.DiskSize.IsNull() && !data.DiskSize.IsUnknown() && !data.DiskSize.Equal(state.DiskSize)
+	if resizeDisk && !state.DiskSize.IsNull() {
+		err := validateInstanceDiskResize(state.DiskSize.ValueInt64(), data.DiskSize.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("disk_size"), "Cannot Resize Disk",
+				fmt.Sprintf("The instance resource with id %q %s.", data.Id.ValueString(), err))
+			return
+		}
 	}
 
 	if !data.ReservationId.IsNull() && !data.ReservationId.IsUnknown() {
@@ -509,7 +845,22 @@ func (r *InstanceResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
+	instance := &instanceResponse.Instance
+
+	if resizeDisk {
+		instance, diag = resizeInstanceDisk(ctx, r.client, instanceId, data.DiskSize.ValueInt64(), data.StopForDiskResize.ValueBool())
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.transitionPowerState(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -538,14 +889,124 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	instanceId := data.Id.ValueString()
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Deletion Protection", deletionProtectionError("instance", instanceId))
+		return
+	}
+
+	if data.FinalSnapshot != nil {
+		snapshotName := data.FinalSnapshot.SnapshotName(data.Name.ValueString(), instanceId, time.Now())
+
+		snapshotId, diag := r.createFinalSnapshot(ctx, instanceId, snapshotName)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Final Snapshot Created",
+			fmt.Sprintf("The final snapshot %q with id %q was created from the instance with id %q before deleting it.", snapshotName, snapshotId, instanceId),
+		)
+	}
+
+	resp.Diagnostics.Append(r.deleteInstance(ctx, instanceId)...)
+}
+
+func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// createInstance creates the instance with the first combination of region and
+// instance type which does not fail due to missing capacity or an exhausted quota.
+func (r *InstanceResource) createInstance(ctx context.Context, data *InstanceResourceModel, body genesiscloud.CreateInstanceJSONRequestBody) (instance *genesiscloud.Instance, diags diag.Diagnostics) {
+	var regionFallbacks []string
+	if !data.RegionFallbacks.IsNull() && !data.RegionFallbacks.IsUnknown() {
+		diags.Append(data.RegionFallbacks.ElementsAs(ctx, &regionFallbacks, false)...)
+	}
+
+	var typeFallbacks []string
+	if !data.TypeFallbacks.IsNull() && !data.TypeFallbacks.IsUnknown() {
+		diags.Append(data.TypeFallbacks.ElementsAs(ctx, &typeFallbacks, false)...)
+	}
+
+	errorCodes := defaultCapacityErrorCodes
+	if !data.FallbackErrorCodes.IsNull() && !data.FallbackErrorCodes.IsUnknown() {
+		diags.Append(data.FallbackErrorCodes.ElementsAs(ctx, &errorCodes, false)...)
+	}
+
+	if diags.HasError() {
+		return
+	}
+
+	candidates := instanceCandidates(string(body.Region), regionFallbacks, string(body.Type), typeFallbacks)
+
+	for i, candidate := range candidates {
+		body.Region = candidate.Region
+		body.Type = candidate.Type
+
+		response, err := r.client.CreateInstanceWithResponse(ctx, body)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("create instance", err))
+			return
+		}
+
+		if response.JSON201 != nil {
+			return &response.JSON201.Instance, diags
+		}
+
+		if !isCapacityError(response.JSONDefault, errorCodes) || i == len(candidates)-1 {
+			diags.AddError("Client Error", generateClientErrorMessage("create instance", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		tflog.Warn(ctx, "instance type unavailable in region, trying the next fallback", map[string]interface{}{
+			"region": candidate.Region,
+			"type":   candidate.Type,
+			"code":   response.JSONDefault.Code,
+			"error":  response.JSONDefault.Message,
+		})
+	}
+
+	return
+}
+
+// waitForProvisioning waits until the instance is active or in error state. The
+// statuses the instance went through are returned to report a failed provisioning.
+func (r *InstanceResource) waitForProvisioning(ctx context.Context, instanceId string) (*genesiscloud.Instance, []genesiscloud.InstanceStatus, diag.Diagnostics) {
+	err := r.client.PollingWait(ctx)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Polling Error", generateErrorMessage("polling instance", err))
+		return nil, nil, diags
+	}
+
+	var statuses []genesiscloud.InstanceStatus
+
+	instance, diags := waitForInstance(ctx, r.client, instanceId, func(instance *genesiscloud.Instance) bool {
+		if len(statuses) == 0 || statuses[len(statuses)-1] != instance.Status {
+			statuses = append(statuses, instance.Status)
+		}
+
+		return instance.Status == genesiscloud.InstanceStatusActive || instance.Status == genesiscloud.InstanceStatusError
+	})
+
+	return instance, statuses, diags
+}
+
+// deleteInstance deletes the instance and waits until it is gone.
+func (r *InstanceResource) deleteInstance(ctx context.Context, instanceId string) (diags diag.Diagnostics) {
 	response, err := r.client.DeleteInstanceWithResponse(ctx, instanceId)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("delete instance", err))
+		diags.AddError("Client Error", generateErrorMessage("delete instance", err))
 		return
 	}
 
 	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("delete instance", ErrorResponse{
+		diags.AddError("Client Error", generateClientErrorMessage("delete instance", ErrorResponse{
 			Body:         response.Body,
 			HTTPResponse: response.HTTPResponse,
 			Error:        response.JSONDefault,
@@ -556,7 +1017,7 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	for {
 		err := r.client.PollingWait(ctx)
 		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance", err))
+			diags.AddError("Polling Error", generateErrorMessage("polling instance", err))
 			return
 		}
 
@@ -564,7 +1025,7 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 
 		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance", err))
+			diags.AddError("Client Error", generateErrorMessage("polling instance", err))
 			return
 		}
 
@@ -574,6 +1035,136 @@ func (r *InstanceResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
-func (r *InstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// waitForReachable waits until the configured port of the instance is reachable.
+func (r *InstanceResource) waitForReachable(ctx context.Context, data *InstanceResourceModel) (diags diag.Diagnostics) {
+	address := data.ProbeAddress()
+	if address == "" {
+		diags.AddError("Instance Not Reachable", fmt.Sprintf("The instance with id %q has no IP address to probe.", data.Id.ValueString()))
+		return
+	}
+
+	timeout, err := data.WaitFor.TimeoutValue()
+	if err != nil {
+		diags.AddError("Invalid Timeout", generateErrorMessage("waiting for instance", err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = waitForPort(ctx, address, data.WaitFor.PortValue(), data.WaitFor.SshBanner.ValueBool(), r.client.PollingInterval)
+	if err != nil {
+		diags.AddError("Instance Not Reachable", generateErrorMessage("waiting for instance", err))
+		return
+	}
+
+	tflog.Trace(ctx, "instance is reachable")
+
+	return
+}
+
+// waitForCloudInit waits until cloud-init is done on the instance.
+func (r *InstanceResource) waitForCloudInit(ctx context.Context, data *InstanceResourceModel, privateKey string) (diags diag.Diagnostics) {
+	address := data.ProbeAddress()
+	if address == "" {
+		diags.AddError("Cloud-Init Error", fmt.Sprintf("The instance with id %q has no IP address to connect to.", data.Id.ValueString()))
+		return
+	}
+
+	timeout, err := data.WaitForCloudInit.TimeoutValue()
+	if err != nil {
+		diags.AddError("Invalid Timeout", generateErrorMessage("waiting for cloud-init", err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err = waitForCloudInit(ctx, data.WaitForCloudInit.CloudInitWait(address, privateKey, r.client.PollingInterval))
+	if err != nil {
+		diags.AddError("Cloud-Init Error", generateErrorMessage("waiting for cloud-init", err))
+		return
+	}
+
+	tflog.Trace(ctx, "cloud-init is done")
+
+	return
+}
+
+// transitionPowerState transitions the instance into the configured power state, if any.
+func (r *InstanceResource) transitionPowerState(ctx context.Context, data *InstanceResourceModel) (diags diag.Diagnostics) {
+	if data.PowerState.IsNull() || data.PowerState.IsUnknown() {
+		return
+	}
+
+	instance, diags := transitionInstanceStatus(ctx, r.client, data.Id.ValueString(), genesiscloud.InstanceStatus(data.PowerState.ValueString()))
+	if diags.HasError() {
+		return
+	}
+
+	diags.Append(data.PopulateFromClientResponse(ctx, instance)...)
+
+	tflog.Trace(ctx, "transitioned the power state of a instance resource", map[string]interface{}{"power_state": data.PowerState.ValueString()})
+
+	return
+}
+
+// createFinalSnapshot creates a snapshot of the instance and waits until it is created.
+func (r *InstanceResource) createFinalSnapshot(ctx context.Context, instanceId string, name string) (snapshotId string, diags diag.Diagnostics) {
+	body := genesiscloud.CreateInstanceSnapshotJSONRequestBody{}
+	body.Name = name
+
+	response, err := r.client.CreateInstanceSnapshotWithResponse(ctx, instanceId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("create final snapshot", err))
+		return
+	}
+
+	snapshotResponse := response.JSON201
+	if snapshotResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage("create final snapshot", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	snapshotId = snapshotResponse.Snapshot.Id
+
+	tflog.Trace(ctx, "created a final snapshot")
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling final snapshot", err))
+			return
+		}
+
+		tflog.Trace(ctx, "polling a final snapshot")
+
+		response, err := r.client.GetSnapshotWithResponse(ctx, snapshotId)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("polling final snapshot", err))
+			return
+		}
+
+		snapshotResponse := response.JSON200
+		if snapshotResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("polling final snapshot", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		switch snapshotResponse.Snapshot.Status {
+		case genesiscloud.SnapshotStatusCreated:
+			return
+		case genesiscloud.SnapshotStatusError:
+			diags.AddError("Provisioning Error", fmt.Sprintf("The final snapshot with id %q is in error state, the instance with id %q was not deleted.", snapshotId, instanceId))
+			return
+		}
+	}
 }
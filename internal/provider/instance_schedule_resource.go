@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource               = &InstanceScheduleResource{}
+	_ resource.ResourceWithConfigure  = &InstanceScheduleResource{}
+	_ resource.ResourceWithModifyPlan = &InstanceScheduleResource{}
+)
+
+func NewInstanceScheduleResource() resource.Resource {
+	return &InstanceScheduleResource{}
+}
+
+// InstanceScheduleResource defines the resource implementation.
+type InstanceScheduleResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *InstanceScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_schedule"
+}
+
+func (r *InstanceScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "InstanceSchedule resource. The instance is started and stopped according to the schedule whenever " +
+			"Terraform is applied, so a periodic `terraform apply` is enough to enforce it.",
+
+		Attributes: map[string]schema.Attribute{
+			"instance_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the instance this refers to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+			"next_transition_at": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The time of the next scheduled start or stop in RFC 3339. " +
+					"It is null if the schedule does not start or stop the instance anymore.",
+				Computed: true,
+			}),
+			"start_schedule": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The cron expression `minute hour day-of-month month day-of-week` of the times the instance is started, e.g. `0 8 * * MON-FRI`.",
+				Required:            true,
+				Validators: []validator.String{
+					cronScheduleValidator{},
+				},
+			}),
+			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The instance status. It is planned to the status the schedule demands at the time of the plan, " +
+					"which is `active` if the last scheduled start is after the last scheduled stop and `stopped` otherwise.",
+				Computed: true,
+			}),
+			"stop_schedule": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The cron expression `minute hour day-of-month month day-of-week` of the times the instance is stopped, e.g. `0 20 * * MON-FRI`.",
+				Required:            true,
+				Validators: []validator.String{
+					cronScheduleValidator{},
+				},
+			}),
+			"timezone": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The IANA time zone the schedules are evaluated in.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(defaultInstanceScheduleTimezone),
+				},
+				Validators: []validator.String{
+					timezoneValidator{},
+				},
+			}),
+
+			// Internal
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *InstanceScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to schedule on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan InstanceScheduleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.IsKnown() {
+		return
+	}
+
+	err := plan.PopulateFromSchedule(time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Schedule", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "evaluated instance schedule", map[string]interface{}{
+		"status":             plan.Status.ValueString(),
+		"next_transition_at": plan.NextTransitionAt.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *InstanceScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data InstanceScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	resp.Diagnostics.Append(r.converge(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created instance schedule resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data InstanceScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	instance, diag := getInstance(ctx, r.client, data.InstanceId.ValueString(), "read instance schedule")
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+
+	// Report the actual status, so the next plan converges the instance to the schedule
+	data.Status = types.StringValue(string(instance.Status))
+
+	_, nextTransitionAt, err := data.Evaluate(time.Now())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Schedule", err.Error())
+		return
+	}
+
+	data.PopulateNextTransitionAt(nextTransitionAt)
+
+	tflog.Trace(ctx, "read instance schedule resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data InstanceScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	resp.Diagnostics.Append(r.converge(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated instance schedule resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *InstanceScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data InstanceScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the resource is not real so its a noop
+}
+
+// converge transitions the instance into the planned status. The status is
+// evaluated now if it was unknown at plan time. The refreshed status is not
+// validated at plan time, as a transient status is waited out here first.
+func (r *InstanceScheduleResource) converge(ctx context.Context, data *InstanceScheduleResourceModel) (diags diag.Diagnostics) {
+	if data.Status.IsUnknown() || data.NextTransitionAt.IsUnknown() {
+		err := data.PopulateFromSchedule(time.Now())
+		if err != nil {
+			diags.AddError("Invalid Schedule", err.Error())
+			return
+		}
+	}
+
+	_, diags = transitionInstanceStatus(ctx, r.client, data.InstanceId.ValueString(), genesiscloud.InstanceStatus(data.Status.ValueString()))
+
+	return
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccInstanceScheduleResourceConfig(startSchedule string, stopSchedule string) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_instance_schedule" "test" {
+  instance_id    = "instance-id"
+  start_schedule = %[1]q
+  stop_schedule  = %[2]q
+}
+`, startSchedule, stopSchedule)
+}
+
+func TestAccInstanceScheduleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccInstanceScheduleResourceConfig("0 8 * * *", "0 20 * * *"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_instance_schedule.test", "timezone", "UTC"),
+					resource.TestCheckResourceAttrSet("genesiscloud_instance_schedule.test", "next_transition_at"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccInstanceScheduleResourceConfig("0 9 * * MON-FRI", "0 18 * * MON-FRI"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_instance_schedule.test", "start_schedule", "0 9 * * MON-FRI"),
+					resource.TestCheckResourceAttrSet("genesiscloud_instance_schedule.test", "next_transition_at"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
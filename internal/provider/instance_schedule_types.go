@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultInstanceScheduleTimezone is the time zone of schedules if none is configured.
+const defaultInstanceScheduleTimezone = "UTC"
+
+type InstanceScheduleResourceModel struct {
+	// InstanceId The id of the instance this refers to.
+	InstanceId types.String `tfsdk:"instance_id"`
+
+	// NextTransitionAt The time of the next scheduled start or stop in RFC 3339.
+	NextTransitionAt types.String `tfsdk:"next_transition_at"`
+
+	// StartSchedule The cron expression of the times the instance is started.
+	StartSchedule types.String `tfsdk:"start_schedule"`
+
+	// Status The instance status according to the schedule.
+	Status types.String `tfsdk:"status"`
+
+	// StopSchedule The cron expression of the times the instance is stopped.
+	StopSchedule types.String `tfsdk:"stop_schedule"`
+
+	// Timezone The time zone the schedules are evaluated in.
+	Timezone types.String `tfsdk:"timezone"`
+
+	// Internal
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// IsKnown reports whether the schedule can be evaluated.
+func (data *InstanceScheduleResourceModel) IsKnown() bool {
+	return !data.StartSchedule.IsUnknown() && !data.StopSchedule.IsUnknown() && !data.Timezone.IsUnknown()
+}
+
+// Evaluate returns the instance status the schedule demands at now and the time
+// of the next transition. If a start and a stop are scheduled at the same time,
+// the stop takes precedence.
+func (data *InstanceScheduleResourceModel) Evaluate(now time.Time) (genesiscloud.InstanceStatus, time.Time, error) {
+	timezone := defaultInstanceScheduleTimezone
+	if !data.Timezone.IsNull() {
+		timezone = data.Timezone.ValueString()
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	start, err := parseCronSchedule(data.StartSchedule.ValueString())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid start_schedule: %w", err)
+	}
+
+	stop, err := parseCronSchedule(data.StopSchedule.ValueString())
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid stop_schedule: %w", err)
+	}
+
+	now = now.In(location)
+
+	if start.Prev(now).After(stop.Prev(now)) {
+		return genesiscloud.InstanceStatusActive, stop.Next(now), nil
+	}
+
+	return genesiscloud.InstanceStatusStopped, start.Next(now), nil
+}
+
+// PopulateFromSchedule sets the status and the next transition according to the schedule at now.
+func (data *InstanceScheduleResourceModel) PopulateFromSchedule(now time.Time) error {
+	status, nextTransitionAt, err := data.Evaluate(now)
+	if err != nil {
+		return err
+	}
+
+	data.Status = types.StringValue(string(status))
+	data.PopulateNextTransitionAt(nextTransitionAt)
+
+	return nil
+}
+
+// PopulateNextTransitionAt sets the next transition, which is null if the schedule never transitions again.
+func (data *InstanceScheduleResourceModel) PopulateNextTransitionAt(nextTransitionAt time.Time) {
+	if nextTransitionAt.IsZero() {
+		data.NextTransitionAt = types.StringNull()
+		return
+	}
+
+	data.NextTransitionAt = types.StringValue(nextTransitionAt.Format(time.RFC3339))
+}
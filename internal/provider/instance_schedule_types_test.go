@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInstanceScheduleResourceModelEvaluate(t *testing.T) {
+	data := InstanceScheduleResourceModel{
+		StartSchedule: types.StringValue("0 8 * * MON-FRI"),
+		StopSchedule:  types.StringValue("0 20 * * *"),
+		Timezone:      types.StringValue("Europe/Berlin"),
+	}
+
+	testCases := []struct {
+		now              time.Time
+		status           genesiscloud.InstanceStatus
+		nextTransitionAt string
+	}{
+		{
+			now:              time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC), // Friday 12:00 in Berlin
+			status:           genesiscloud.InstanceStatusActive,
+			nextTransitionAt: "2024-05-17T20:00:00+02:00",
+		},
+		{
+			now:              time.Date(2024, 5, 17, 18, 0, 0, 0, time.UTC), // Friday 20:00 in Berlin
+			status:           genesiscloud.InstanceStatusStopped,
+			nextTransitionAt: "2024-05-20T08:00:00+02:00",
+		},
+		{
+			now:              time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC), // Saturday
+			status:           genesiscloud.InstanceStatusStopped,
+			nextTransitionAt: "2024-05-20T08:00:00+02:00",
+		},
+		{
+			now:              time.Date(2024, 5, 20, 5, 59, 0, 0, time.UTC), // Monday 07:59 in Berlin
+			status:           genesiscloud.InstanceStatusStopped,
+			nextTransitionAt: "2024-05-20T08:00:00+02:00",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.now.Format(time.RFC3339), func(t *testing.T) {
+			model := data
+
+			err := model.PopulateFromSchedule(testCase.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if model.Status.ValueString() != string(testCase.status) {
+				t.Errorf("expected status %q, got %q", testCase.status, model.Status.ValueString())
+			}
+
+			if model.NextTransitionAt.ValueString() != testCase.nextTransitionAt {
+				t.Errorf("expected next transition at %q, got %q", testCase.nextTransitionAt, model.NextTransitionAt.ValueString())
+			}
+		})
+	}
+}
+
+func TestInstanceScheduleResourceModelEvaluateInvalid(t *testing.T) {
+	testCases := map[string]InstanceScheduleResourceModel{
+		"start_schedule": {
+			StartSchedule: types.StringValue("0 8 * *"),
+			StopSchedule:  types.StringValue("0 20 * * *"),
+			Timezone:      types.StringValue("UTC"),
+		},
+		"stop_schedule": {
+			StartSchedule: types.StringValue("0 8 * * *"),
+			StopSchedule:  types.StringValue("0 25 * * *"),
+			Timezone:      types.StringValue("UTC"),
+		},
+		"timezone": {
+			StartSchedule: types.StringValue("0 8 * * *"),
+			StopSchedule:  types.StringValue("0 20 * * *"),
+			Timezone:      types.StringValue("Mars/Olympus_Mons"),
+		},
+	}
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := data.Evaluate(time.Now())
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestInstanceScheduleResourceModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	r := &InstanceScheduleResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	timeoutsType := schemaResp.Schema.Attributes["timeouts"].GetType().(timeouts.Type)
+
+	// The refreshed status is not validated, the transition happens on apply
+	for _, current := range allInstanceStatuses {
+		t.Run(string(current), func(t *testing.T) {
+			data := InstanceScheduleResourceModel{
+				InstanceId:       types.StringValue(fakeInstanceId),
+				NextTransitionAt: types.StringNull(),
+				StartSchedule:    types.StringValue("* * * * *"),
+				Status:           types.StringValue(string(current)),
+				StopSchedule:     types.StringValue("0 0 29 2 *"),
+				Timezone:         types.StringValue(defaultInstanceScheduleTimezone),
+				Timeouts:         timeouts.Value{Object: types.ObjectNull(timeoutsType.AttrTypes)},
+			}
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			diags := state.Set(ctx, &data)
+
+			data.Status = types.StringUnknown()
+			data.NextTransitionAt = types.StringUnknown()
+
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			diags.Append(plan.Set(ctx, &data)...)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var status types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("status"), &status)...)
+			if status.ValueString() != string(genesiscloud.InstanceStatusActive) {
+				t.Errorf("expected the planned status %q, got %q", genesiscloud.InstanceStatusActive, status.ValueString())
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package provider
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -51,14 +52,46 @@ func (r *InstanceStatusResource) Schema(ctx context.Context, req resource.Schema
 					stringplanmodifier.RequiresReplace(),
 				},
 			}),
+			"mode": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "How a drift of the instance status, e.g. an instance stopped by hand, is handled. " +
+					"`enforce` reports the actual status, so the next plan shows the drift and the apply corrects it. " +
+					"`observe` only reports a warning.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(InstanceStatusModeEnforce),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(InstanceStatusModeEnforce, InstanceStatusModeObserve),
+				},
+			}),
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The target instance status.",
+				MarkdownDescription: "The target instance status. If the instance is in a transient status, e.g. `stopping`, it is waited for the instance to settle first.",
 				Required:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf(string(genesiscloud.InstanceStatusActive), string(genesiscloud.InstanceStatusStopped)),
 				},
 			}),
 
+			"trigger_action": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The action performed when `triggers` change. " +
+					"`reboot` stops and starts the instance, `reset` performs a hard reset.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					defaultplanmodifier.String(InstanceStatusActionReboot),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(InstanceStatusActionReboot, InstanceStatusActionReset),
+				},
+			}),
+			"triggers": resourceenhancer.Attribute(ctx, schema.MapAttribute{
+				ElementType: types.StringType,
+				MarkdownDescription: "Arbitrary values that cause the `trigger_action` to be performed whenever they change, similar to `triggers_replace` of `terraform_data`. " +
+					"The action is only performed if the target `status` is `active` and not when the resource is created.",
+				Optional: true,
+			}),
+
 			// Internal
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
@@ -84,118 +117,21 @@ func (r *InstanceStatusResource) Create(ctx context.Context, req resource.Create
 	instanceId := data.InstanceId.ValueString()
 	targetStatus := genesiscloud.InstanceStatus(data.Status.ValueString())
 
-	var instanceAction genesiscloud.InstanceAction
-
-	{
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("get instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("get instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-
-		if targetStatus == genesiscloud.InstanceStatusActive &&
-			instanceResponse.Instance.Status == genesiscloud.InstanceStatusStopped {
-
-			instanceAction = genesiscloud.InstanceActionStart
-		} else if targetStatus == genesiscloud.InstanceStatusStopped &&
-			(instanceResponse.Instance.Status == genesiscloud.InstanceStatusActive ||
-				instanceResponse.Instance.Status == genesiscloud.InstanceStatusStarting ||
-				instanceResponse.Instance.Status == genesiscloud.InstanceStatusError) {
-
-			instanceAction = genesiscloud.InstanceActionStop
-		} else {
-			resp.Diagnostics.AddError("Cannot transition instance status",
-				fmt.Sprintf("The instance resource with id %q cannot be transitioned from %q status to %q status. If the current status is transient (ending in 'ing' e.g. stopping) waiting a bit is usually enough.",
-					instanceId, instanceResponse.Instance.Status, targetStatus))
-			return
-		}
-	}
-
-	body := genesiscloud.PerformInstanceActionJSONRequestBody{}
-	body.Action = instanceAction
-
-	response, err := r.client.PerformInstanceActionWithResponse(ctx, instanceId, body)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("perform instance action", err))
+	instance, diag := transitionInstanceStatus(ctx, r.client, instanceId, targetStatus)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
 		return
 	}
 
-	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("perform instance action", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": body.Action})
+	tflog.Trace(ctx, "created instance status resource")
 
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		tflog.Trace(ctx, "polling instance status")
-
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-	}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InstanceStatusResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -252,6 +188,14 @@ func (r *InstanceStatusResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	var state InstanceStatusResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
@@ -262,118 +206,33 @@ func (r *InstanceStatusResource) Update(ctx context.Context, req resource.Update
 	instanceId := data.InstanceId.ValueString()
 	targetStatus := genesiscloud.InstanceStatus(data.Status.ValueString())
 
-	var instanceAction genesiscloud.InstanceAction
-
-	{
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("get instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("get instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-
-		if targetStatus == genesiscloud.InstanceStatusActive &&
-			instanceResponse.Instance.Status == genesiscloud.InstanceStatusStopped {
-
-			instanceAction = genesiscloud.InstanceActionStart
-		} else if targetStatus == genesiscloud.InstanceStatusStopped &&
-			(instanceResponse.Instance.Status == genesiscloud.InstanceStatusActive ||
-				instanceResponse.Instance.Status == genesiscloud.InstanceStatusStarting ||
-				instanceResponse.Instance.Status == genesiscloud.InstanceStatusError) {
+	instance, diag := transitionInstanceStatus(ctx, r.client, instanceId, targetStatus)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
 
-			instanceAction = genesiscloud.InstanceActionStop
+	if !data.Triggers.Equal(state.Triggers) {
+		if targetStatus == genesiscloud.InstanceStatusActive {
+			instance, diag = restartInstance(ctx, r.client, instanceId, data.TriggerAction.ValueString())
+			if diag.HasError() {
+				resp.Diagnostics.Append(diag...)
+				return
+			}
 		} else {
-			resp.Diagnostics.AddError("Cannot transition instance status",
-				fmt.Sprintf("The instance resource with id %q cannot be transitioned from %q status to %q status. If the current status is transient (ending in 'ing' e.g. stopping) waiting a bit is usually enough.",
-					instanceId, instanceResponse.Instance.Status, targetStatus))
-			return
+			tflog.Debug(ctx, "triggers changed but the instance is not active, skipping the trigger action")
 		}
 	}
 
-	body := genesiscloud.PerformInstanceActionJSONRequestBody{}
-	body.Action = instanceAction
-
-	response, err := r.client.PerformInstanceActionWithResponse(ctx, instanceId, body)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", generateErrorMessage("perform instance action", err))
-		return
-	}
-
-	if response.StatusCode() != 204 {
-		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("perform instance action", ErrorResponse{
-			Body:         response.Body,
-			HTTPResponse: response.HTTPResponse,
-			Error:        response.JSONDefault,
-		}))
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, instance)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": body.Action})
-
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		tflog.Trace(ctx, "polling instance status")
+	tflog.Trace(ctx, "updated instance status resource")
 
-		response, err := r.client.GetInstanceWithResponse(ctx, instanceId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling instance status", err))
-			return
-		}
-
-		instanceResponse := response.JSON200
-		if instanceResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling instance status", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
-
-		resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &instanceResponse.Instance)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// Save data into Terraform state
-		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		if instanceResponse.Instance.Status == targetStatus {
-			return
-		}
-	}
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InstanceStatusResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
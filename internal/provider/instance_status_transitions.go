@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// InstanceStatusModeEnforce reports the actual instance status, so a drift is corrected by the next apply.
+	InstanceStatusModeEnforce = "enforce"
+
+	// InstanceStatusModeObserve keeps the target instance status and only warns about a drift.
+	InstanceStatusModeObserve = "observe"
+)
+
+const (
+	// InstanceStatusActionReboot stops and starts the instance again.
+	InstanceStatusActionReboot = "reboot"
+
+	// InstanceStatusActionReset performs a hard reset of the instance.
+	InstanceStatusActionReset = "reset"
+)
+
+// transientInstanceStatuses are the statuses of an instance in the middle of
+// a transition, which settle on their own.
+var transientInstanceStatuses = map[genesiscloud.InstanceStatus]bool{
+	genesiscloud.InstanceStatusEnqueued: true,
+	genesiscloud.InstanceStatusCreating: true,
+	genesiscloud.InstanceStatusStarting: true,
+	genesiscloud.InstanceStatusStopping: true,
+}
+
+// isTransientInstanceStatus reports whether the instance is in the middle of a
+// transition, e.g. `starting` or `stopping`, and will settle on its own.
+func isTransientInstanceStatus(status genesiscloud.InstanceStatus) bool {
+	return transientInstanceStatuses[status]
+}
+
+// isInstancePowerState reports whether the status is one of the power states
+// an instance can be transitioned into, i.e. `active` or `stopped`.
+func isInstancePowerState(status genesiscloud.InstanceStatus) bool {
+	return status == genesiscloud.InstanceStatusActive || status == genesiscloud.InstanceStatusStopped
+}
+
+// instanceStatusAction returns the action which transitions an instance from
+// the current to the target status. The action is empty if the instance is
+// already in the target status.
+func instanceStatusAction(current, target genesiscloud.InstanceStatus) (genesiscloud.InstanceAction, error) {
+	switch {
+	case current == target:
+		return "", nil
+	case target == genesiscloud.InstanceStatusActive && current == genesiscloud.InstanceStatusStopped:
+		return genesiscloud.InstanceActionStart, nil
+	case target == genesiscloud.InstanceStatusStopped &&
+		(current == genesiscloud.InstanceStatusActive || current == genesiscloud.InstanceStatusError):
+		return genesiscloud.InstanceActionStop, nil
+	default:
+		return "", fmt.Errorf("cannot transition from %q status to %q status", current, target)
+	}
+}
+
+// validateInstanceStatusTransition returns an error if the instance cannot be
+// transitioned from the current to the target status. Transient statuses are
+// waited out before the transition and are therefore not rejected.
+func validateInstanceStatusTransition(current, target genesiscloud.InstanceStatus) error {
+	if isTransientInstanceStatus(current) {
+		return nil
+	}
+
+	_, err := instanceStatusAction(current, target)
+	return err
+}
+
+// instanceStatusStrings converts the statuses for messages and logs.
+func instanceStatusStrings(statuses []genesiscloud.InstanceStatus) []string {
+	result := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, string(status))
+	}
+	return result
+}
+
+// instanceProvisioningDetails describes an instance whose provisioning failed
+// with the statuses it went through, as the API reports no further details.
+func instanceProvisioningDetails(instance *genesiscloud.Instance, statuses []genesiscloud.InstanceStatus) string {
+	return fmt.Sprintf("instance with id %q of type %q in region %q went through the statuses %s and was last updated at %s",
+		instance.Id, instance.Type, instance.Region, strings.Join(instanceStatusStrings(statuses), ", "), instance.UpdatedAt.Format(time.RFC3339))
+}
+
+// getInstance returns the current state of the instance.
+func getInstance(ctx context.Context, client *Client, instanceId string, verb string) (*genesiscloud.Instance, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := client.GetInstanceWithResponse(ctx, instanceId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return nil, diags
+	}
+
+	instanceResponse := response.JSON200
+	if instanceResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &instanceResponse.Instance, diags
+}
+
+// waitForInstance polls the instance until done returns true.
+func waitForInstance(ctx context.Context, client *Client, instanceId string, done func(instance *genesiscloud.Instance) bool) (*genesiscloud.Instance, diag.Diagnostics) {
+	for {
+		instance, diags := getInstance(ctx, client, instanceId, "polling instance status")
+		if diags.HasError() || done(instance) {
+			return instance, diags
+		}
+
+		tflog.Trace(ctx, "polling instance status")
+
+		err := client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling instance status", err))
+			return nil, diags
+		}
+	}
+}
+
+// waitForStableInstance waits until the instance is not in a transient status anymore.
+func waitForStableInstance(ctx context.Context, client *Client, instanceId string) (*genesiscloud.Instance, diag.Diagnostics) {
+	return waitForInstance(ctx, client, instanceId, func(instance *genesiscloud.Instance) bool {
+		return !isTransientInstanceStatus(instance.Status)
+	})
+}
+
+// performInstanceAction performs the action on the instance without waiting for its result.
+func performInstanceAction(ctx context.Context, client *Client, instanceId string, action genesiscloud.InstanceAction) (diags diag.Diagnostics) {
+	body := genesiscloud.PerformInstanceActionJSONRequestBody{}
+	body.Action = action
+
+	response, err := client.PerformInstanceActionWithResponse(ctx, instanceId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("perform instance action", err))
+		return
+	}
+
+	if response.StatusCode() != 204 {
+		diags.AddError("Client Error", generateClientErrorMessage("perform instance action", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	tflog.Trace(ctx, "performed instance action", map[string]interface{}{"action": body.Action})
+
+	return
+}
+
+// transitionInstanceStatus transitions the instance into the target status and
+// waits until it is reached. A transient current status is waited out first.
+func transitionInstanceStatus(ctx context.Context, client *Client, instanceId string, target genesiscloud.InstanceStatus) (*genesiscloud.Instance, diag.Diagnostics) {
+	instance, diags := waitForStableInstance(ctx, client, instanceId)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	action, err := instanceStatusAction(instance.Status, target)
+	if err != nil {
+		diags.AddError("Cannot transition instance status",
+			fmt.Sprintf("The instance resource with id %q %s.", instanceId, err))
+		return nil, diags
+	}
+
+	if action == "" {
+		return instance, diags
+	}
+
+	diags.Append(performInstanceAction(ctx, client, instanceId, action)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	instance, diags = waitForInstance(ctx, client, instanceId, func(instance *genesiscloud.Instance) bool {
+		return instance.Status == target || instance.Status == genesiscloud.InstanceStatusError
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if instance.Status != target {
+		diags.AddError("Provisioning Error", generateErrorMessage("polling instance status", ErrResourceInErrorState))
+		return instance, diags
+	}
+
+	return instance, diags
+}
+
+// restartInstance reboots or resets an active instance and waits until it is active again.
+func restartInstance(ctx context.Context, client *Client, instanceId string, statusAction string) (*genesiscloud.Instance, diag.Diagnostics) {
+	if statusAction == InstanceStatusActionReboot {
+		_, diags := transitionInstanceStatus(ctx, client, instanceId, genesiscloud.InstanceStatusStopped)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		return transitionInstanceStatus(ctx, client, instanceId, genesiscloud.InstanceStatusActive)
+	}
+
+	diags := performInstanceAction(ctx, client, instanceId, genesiscloud.InstanceActionReset)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// Give the reset a chance to show up in the status before waiting for the instance to settle
+	err := client.PollingWait(ctx)
+	if err != nil {
+		diags.AddError("Polling Error", generateErrorMessage("polling instance status", err))
+		return nil, diags
+	}
+
+	return transitionInstanceStatus(ctx, client, instanceId, genesiscloud.InstanceStatusActive)
+}
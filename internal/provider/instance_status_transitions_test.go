@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const fakeInstanceId = "fake-instance"
+
+var (
+	allInstanceStatuses = []genesiscloud.InstanceStatus{
+		genesiscloud.InstanceStatusActive,
+		genesiscloud.InstanceStatusStopped,
+		genesiscloud.InstanceStatusStarting,
+		genesiscloud.InstanceStatusStopping,
+		genesiscloud.InstanceStatusCreating,
+		genesiscloud.InstanceStatusError,
+	}
+
+	targetInstanceStatuses = []genesiscloud.InstanceStatus{
+		genesiscloud.InstanceStatusActive,
+		genesiscloud.InstanceStatusStopped,
+	}
+)
+
+// fakeInstanceAPI serves a single instance whose transient statuses settle
+// after one poll, like the real API does after a while.
+type fakeInstanceAPI struct {
+	mu      sync.Mutex
+	status  genesiscloud.InstanceStatus
+	actions []genesiscloud.InstanceAction
+
+	// diskSize is reported once the requested disk resize settled after one poll.
+	diskSize        int
+	pendingDiskSize int
+
+	// resizeRequiresStop rejects disk resizes of a running instance.
+	resizeRequiresStop bool
+
+	// resizeErrorCode rejects disk resizes with an error of the code, if set.
+	resizeErrorCode string
+}
+
+func (f *fakeInstanceAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/instances/"+fakeInstanceId+"/actions"):
+		var body struct {
+			Action genesiscloud.InstanceAction `json:"action"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch {
+		case body.Action == genesiscloud.InstanceActionStart && f.status == genesiscloud.InstanceStatusStopped:
+			f.status = genesiscloud.InstanceStatusStarting
+		case body.Action == genesiscloud.InstanceActionStop &&
+			(f.status == genesiscloud.InstanceStatusActive || f.status == genesiscloud.InstanceStatusError):
+			f.status = genesiscloud.InstanceStatusStopping
+		default:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"code":"conflict","message":"invalid action for the current instance status"}`))
+			return
+		}
+
+		f.actions = append(f.actions, body.Action)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/instances/"+fakeInstanceId):
+		var body struct {
+			DiskSize *int `json:"disk_size"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if body.DiskSize != nil {
+			if f.resizeErrorCode != "" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = fmt.Fprintf(w, `{"code":%q,"message":"the disk cannot be resized"}`, f.resizeErrorCode)
+				return
+			}
+
+			if f.resizeRequiresStop && f.status != genesiscloud.InstanceStatusStopped {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"code":"invalid_request","message":"the instance has to be stopped to resize its disk"}`))
+				return
+			}
+
+			f.pendingDiskSize = *body.DiskSize
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"instance": map[string]interface{}{
+				"id":     fakeInstanceId,
+				"status": f.status,
+			},
+		})
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/instances/"+fakeInstanceId):
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"instance": map[string]interface{}{
+				"id":        fakeInstanceId,
+				"status":    f.status,
+				"disk_size": f.diskSize,
+			},
+		})
+
+		if f.pendingDiskSize != 0 {
+			f.diskSize = f.pendingDiskSize
+			f.pendingDiskSize = 0
+		}
+
+		switch f.status {
+		case genesiscloud.InstanceStatusStarting, genesiscloud.InstanceStatusCreating:
+			f.status = genesiscloud.InstanceStatusActive
+		case genesiscloud.InstanceStatusStopping:
+			f.status = genesiscloud.InstanceStatusStopped
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found","message":"not found"}`))
+	}
+}
+
+func newFakeInstanceClient(t *testing.T, status genesiscloud.InstanceStatus) (*Client, *fakeInstanceAPI) {
+	t.Helper()
+
+	api := &fakeInstanceAPI{status: status}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), ClientConfig{
+		ClientConfig: genesiscloud.ClientConfig{
+			Endpoint: server.URL,
+			Token:    "fake-token",
+		},
+		PollingInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	return client, api
+}
+
+func TestTransitionInstanceStatus(t *testing.T) {
+	testCases := map[genesiscloud.InstanceStatus]map[genesiscloud.InstanceStatus]struct {
+		actions []genesiscloud.InstanceAction
+		err     bool
+	}{
+		genesiscloud.InstanceStatusActive: {
+			genesiscloud.InstanceStatusActive:  {},
+			genesiscloud.InstanceStatusStopped: {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop}},
+		},
+		genesiscloud.InstanceStatusStopped: {
+			genesiscloud.InstanceStatusActive:  {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStart}},
+			genesiscloud.InstanceStatusStopped: {},
+		},
+		genesiscloud.InstanceStatusStarting: {
+			genesiscloud.InstanceStatusActive:  {},
+			genesiscloud.InstanceStatusStopped: {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop}},
+		},
+		genesiscloud.InstanceStatusStopping: {
+			genesiscloud.InstanceStatusActive:  {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStart}},
+			genesiscloud.InstanceStatusStopped: {},
+		},
+		genesiscloud.InstanceStatusCreating: {
+			genesiscloud.InstanceStatusActive:  {},
+			genesiscloud.InstanceStatusStopped: {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop}},
+		},
+		genesiscloud.InstanceStatusError: {
+			genesiscloud.InstanceStatusActive:  {err: true},
+			genesiscloud.InstanceStatusStopped: {actions: []genesiscloud.InstanceAction{genesiscloud.InstanceActionStop}},
+		},
+	}
+
+	for _, current := range allInstanceStatuses {
+		for _, target := range targetInstanceStatuses {
+			testCase := testCases[current][target]
+
+			t.Run(string(current)+"->"+string(target), func(t *testing.T) {
+				client, api := newFakeInstanceClient(t, current)
+
+				instance, diags := transitionInstanceStatus(context.Background(), client, fakeInstanceId, target)
+
+				if testCase.err {
+					if !diags.HasError() {
+						t.Fatalf("expected an error, got status %q", instance.Status)
+					}
+				} else {
+					if diags.HasError() {
+						t.Fatalf("unexpected error: %v", diags)
+					}
+					if instance.Status != target {
+						t.Errorf("expected status %q, got %q", target, instance.Status)
+					}
+				}
+
+				if strings.Join(instanceActionStrings(api.actions), ",") != strings.Join(instanceActionStrings(testCase.actions), ",") {
+					t.Errorf("expected actions %v, got %v", testCase.actions, api.actions)
+				}
+			})
+		}
+	}
+}
+
+func TestIsTransientInstanceStatus(t *testing.T) {
+	testCases := map[genesiscloud.InstanceStatus]bool{
+		genesiscloud.InstanceStatusEnqueued: true,
+		genesiscloud.InstanceStatusCreating: true,
+		genesiscloud.InstanceStatusStarting: true,
+		genesiscloud.InstanceStatusStopping: true,
+		genesiscloud.InstanceStatusActive:   false,
+		genesiscloud.InstanceStatusStopped:  false,
+		genesiscloud.InstanceStatusError:    false,
+		"deleting":                          false,
+		"pending_billing":                   false,
+	}
+
+	for status, expected := range testCases {
+		t.Run(string(status), func(t *testing.T) {
+			if actual := isTransientInstanceStatus(status); actual != expected {
+				t.Errorf("expected %t, got %t", expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsInstancePowerState(t *testing.T) {
+	testCases := map[genesiscloud.InstanceStatus]bool{
+		genesiscloud.InstanceStatusActive:   true,
+		genesiscloud.InstanceStatusStopped:  true,
+		genesiscloud.InstanceStatusStarting: false,
+		genesiscloud.InstanceStatusStopping: false,
+		genesiscloud.InstanceStatusError:    false,
+		"deleting":                          false,
+	}
+
+	for status, expected := range testCases {
+		t.Run(string(status), func(t *testing.T) {
+			if actual := isInstancePowerState(status); actual != expected {
+				t.Errorf("expected %t, got %t", expected, actual)
+			}
+		})
+	}
+}
+
+func TestInstanceProvisioningDetails(t *testing.T) {
+	instance := &genesiscloud.Instance{
+		Id:        "instance-1",
+		Region:    "NORD-NO-KRS-1",
+		Status:    genesiscloud.InstanceStatusError,
+		Type:      "vcpu-4_memory-12g",
+		UpdatedAt: time.Date(2024, 5, 17, 10, 30, 45, 0, time.UTC),
+	}
+
+	statuses := []genesiscloud.InstanceStatus{genesiscloud.InstanceStatusEnqueued, genesiscloud.InstanceStatusCreating, genesiscloud.InstanceStatusError}
+
+	expected := `instance with id "instance-1" of type "vcpu-4_memory-12g" in region "NORD-NO-KRS-1" went through the statuses enqueued, creating, error and was last updated at 2024-05-17T10:30:45Z`
+
+	if actual := instanceProvisioningDetails(instance, statuses); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestValidateInstanceStatusTransition(t *testing.T) {
+	for _, current := range allInstanceStatuses {
+		for _, target := range targetInstanceStatuses {
+			t.Run(string(current)+"->"+string(target), func(t *testing.T) {
+				err := validateInstanceStatusTransition(current, target)
+
+				invalid := current == genesiscloud.InstanceStatusError && target == genesiscloud.InstanceStatusActive
+				if invalid && err == nil {
+					t.Errorf("expected an error")
+				}
+				if !invalid && err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			})
+		}
+	}
+}
+
+func TestInstanceStatusResourceModelDrift(t *testing.T) {
+	for _, mode := range []string{InstanceStatusModeEnforce, InstanceStatusModeObserve} {
+		for _, current := range allInstanceStatuses {
+			for _, target := range targetInstanceStatuses {
+				t.Run(mode+"/"+string(current)+"->"+string(target), func(t *testing.T) {
+					client, api := newFakeInstanceClient(t, current)
+
+					instance, diags := getInstance(context.Background(), client, fakeInstanceId, "read instance status")
+					if diags.HasError() {
+						t.Fatalf("unexpected error: %v", diags)
+					}
+
+					data := InstanceStatusResourceModel{
+						InstanceId: types.StringValue(fakeInstanceId),
+						Mode:       types.StringValue(mode),
+						Status:     types.StringValue(string(target)),
+					}
+
+					diags = data.PopulateFromClientResponse(context.Background(), instance)
+					if diags.HasError() {
+						t.Fatalf("unexpected error: %v", diags)
+					}
+
+					drift := current != target
+
+					expectedStatus := target
+					if mode == InstanceStatusModeEnforce {
+						expectedStatus = current
+					}
+					if data.Status.ValueString() != string(expectedStatus) {
+						t.Errorf("expected status %q, got %q", expectedStatus, data.Status.ValueString())
+					}
+
+					expectedWarnings := 0
+					if mode == InstanceStatusModeObserve && drift {
+						expectedWarnings = 1
+					}
+					if diags.WarningsCount() != expectedWarnings {
+						t.Errorf("expected %d warnings, got %d", expectedWarnings, diags.WarningsCount())
+					}
+
+					if len(api.actions) != 0 {
+						t.Errorf("expected no actions while reading, got %v", api.actions)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestInstanceStatusResourceModelImport(t *testing.T) {
+	data := InstanceStatusResourceModel{
+		InstanceId: types.StringValue(fakeInstanceId),
+		Mode:       types.StringNull(),
+		Status:     types.StringNull(),
+	}
+
+	diags := data.PopulateFromClientResponse(context.Background(), &genesiscloud.Instance{
+		Id:     fakeInstanceId,
+		Status: genesiscloud.InstanceStatusStopped,
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.Mode.ValueString() != InstanceStatusModeEnforce {
+		t.Errorf("expected mode %q, got %q", InstanceStatusModeEnforce, data.Mode.ValueString())
+	}
+	if data.Status.ValueString() != string(genesiscloud.InstanceStatusStopped) {
+		t.Errorf("expected status %q, got %q", genesiscloud.InstanceStatusStopped, data.Status.ValueString())
+	}
+}
+
+func instanceActionStrings(actions []genesiscloud.InstanceAction) []string {
+	result := make([]string, 0, len(actions))
+	for _, action := range actions {
+		result = append(result, string(action))
+	}
+	return result
+}
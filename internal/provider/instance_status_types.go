@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/genesiscloud/genesiscloud-go"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -13,9 +14,18 @@ type InstanceStatusResourceModel struct {
 	// InstanceId The id of the instance this refers to.
 	InstanceId types.String `tfsdk:"instance_id"`
 
+	// Mode How a drift of the instance status is handled.
+	Mode types.String `tfsdk:"mode"`
+
 	// Status The target instance status.
 	Status types.String `tfsdk:"status"`
 
+	// TriggerAction The action performed when the triggers change.
+	TriggerAction types.String `tfsdk:"trigger_action"`
+
+	// Triggers Arbitrary values that cause the trigger action to be performed whenever they change.
+	Triggers types.Map `tfsdk:"triggers"`
+
 	// Internal
 
 	// Timeouts The resource timeouts
@@ -24,6 +34,23 @@ type InstanceStatusResourceModel struct {
 
 func (data *InstanceStatusResourceModel) PopulateFromClientResponse(ctx context.Context, instance *genesiscloud.Instance) (diag diag.Diagnostics) {
 	data.InstanceId = types.StringValue(instance.Id)
+
+	if data.Mode.IsNull() || data.Mode.IsUnknown() {
+		data.Mode = types.StringValue(InstanceStatusModeEnforce)
+	}
+
+	// In observe mode the target status is kept, so a drift does not cause changes
+	if data.Mode.ValueString() == InstanceStatusModeObserve && !data.Status.IsNull() && !data.Status.IsUnknown() {
+		if string(instance.Status) != data.Status.ValueString() {
+			diag.AddWarning(
+				"Instance Status Drift",
+				fmt.Sprintf("The instance with id %q has the status %q instead of the target status %q.", instance.Id, instance.Status, data.Status.ValueString()),
+			)
+		}
+
+		return
+	}
+
 	data.Status = types.StringValue(string(instance.Status))
 
 	return
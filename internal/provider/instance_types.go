@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/genesiscloud/genesiscloud-go"
@@ -17,6 +18,110 @@ type InstanceMetadataModel struct {
 	StartupScript types.String `tfsdk:"startup_script"`
 }
 
+// defaultProvisioningRetryDelay is the time to wait before an instance in error state is created again if none is configured.
+const defaultProvisioningRetryDelay = "30s"
+
+// defaultFinalSnapshotNameTemplate is the name template of final snapshots if none is configured.
+const defaultFinalSnapshotNameTemplate = "{name}-final-{timestamp}"
+
+type InstanceFinalSnapshotModel struct {
+	// NameTemplate The name of the snapshot with the placeholders `{name}`, `{id}` and `{timestamp}`.
+	NameTemplate types.String `tfsdk:"name_template"`
+}
+
+// SnapshotName returns the name of the final snapshot of the instance.
+func (data *InstanceFinalSnapshotModel) SnapshotName(instanceName string, instanceId string, now time.Time) string {
+	template := defaultFinalSnapshotNameTemplate
+	if !data.NameTemplate.IsNull() && !data.NameTemplate.IsUnknown() {
+		template = data.NameTemplate.ValueString()
+	}
+
+	return strings.NewReplacer(
+		"{name}", instanceName,
+		"{id}", instanceId,
+		"{timestamp}", now.UTC().Format("20060102-150405"),
+	).Replace(template)
+}
+
+type InstanceWaitForModel struct {
+	// Port The TCP port which has to be reachable.
+	Port types.Int64 `tfsdk:"port"`
+
+	// SshBanner Flag to also wait for the server on the port to send an SSH banner.
+	SshBanner types.Bool `tfsdk:"ssh_banner"`
+
+	// Timeout The time the port is probed for.
+	Timeout types.String `tfsdk:"timeout"`
+}
+
+// PortValue returns the configured port or the default port.
+func (data *InstanceWaitForModel) PortValue() int {
+	if data.Port.IsNull() || data.Port.IsUnknown() {
+		return defaultWaitForPort
+	}
+
+	return int(data.Port.ValueInt64())
+}
+
+// TimeoutValue returns the configured timeout or the default timeout.
+func (data *InstanceWaitForModel) TimeoutValue() (time.Duration, error) {
+	if data.Timeout.IsNull() || data.Timeout.IsUnknown() {
+		return time.ParseDuration(defaultWaitForTimeout)
+	}
+
+	return time.ParseDuration(data.Timeout.ValueString())
+}
+
+type InstanceWaitForCloudInitModel struct {
+	// Port The SSH port.
+	Port types.Int64 `tfsdk:"port"`
+
+	// PrivateKey The private key of one of the SSH keys of the instance. It is
+	// write-only, so it is always null in the plan and state.
+	PrivateKey types.String `tfsdk:"private_key"`
+
+	// SentinelFile The file whose existence is waited for instead of cloud-init.
+	SentinelFile types.String `tfsdk:"sentinel_file"`
+
+	// Timeout The time waited for cloud-init.
+	Timeout types.String `tfsdk:"timeout"`
+
+	// User The user to connect as.
+	User types.String `tfsdk:"user"`
+}
+
+// CloudInitWait returns the configuration to wait for cloud-init on the address,
+// connecting with the private key from the configuration.
+func (data *InstanceWaitForCloudInitModel) CloudInitWait(address string, privateKey string, interval time.Duration) cloudInitWait {
+	wait := cloudInitWait{
+		Address:      address,
+		Port:         defaultWaitForPort,
+		User:         defaultCloudInitUser,
+		PrivateKey:   privateKey,
+		SentinelFile: data.SentinelFile.ValueString(),
+		Interval:     interval,
+	}
+
+	if !data.Port.IsNull() && !data.Port.IsUnknown() {
+		wait.Port = int(data.Port.ValueInt64())
+	}
+
+	if !data.User.IsNull() && !data.User.IsUnknown() {
+		wait.User = data.User.ValueString()
+	}
+
+	return wait
+}
+
+// TimeoutValue returns the configured timeout or the default timeout.
+func (data *InstanceWaitForCloudInitModel) TimeoutValue() (time.Duration, error) {
+	if data.Timeout.IsNull() || data.Timeout.IsUnknown() {
+		return time.ParseDuration(defaultCloudInitTimeout)
+	}
+
+	return time.ParseDuration(data.Timeout.ValueString())
+}
+
 type InstanceResourceModel struct {
 	CreatedAt types.String `tfsdk:"created_at"`
 
@@ -38,9 +143,24 @@ type InstanceResourceModel struct {
 	// Metadata Option to provide metadata. Currently supported is `startup_script`.
 	Metadata *InstanceMetadataModel `tfsdk:"metadata"`
 
+	// EffectiveRegion The region the instance was created in, which is `region` or one of `region_fallbacks`.
+	EffectiveRegion types.String `tfsdk:"effective_region"`
+
+	// EffectiveType The instance type the instance was created with, which is `type` or one of `type_fallbacks`.
+	EffectiveType types.String `tfsdk:"effective_type"`
+
+	// DeletionProtection Flag to protect the instance from being destroyed or replaced.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// DiskSize The disk size of the instance in GiB.
 	DiskSize types.Int64 `tfsdk:"disk_size"`
 
+	// FallbackErrorCodes The API error codes of a failed creation on which the next fallback is tried.
+	FallbackErrorCodes types.List `tfsdk:"fallback_error_codes"`
+
+	// FinalSnapshot Option to create a snapshot of the instance before it is destroyed.
+	FinalSnapshot *InstanceFinalSnapshotModel `tfsdk:"final_snapshot"`
+
 	// Name The human-readable name for the instance.
 	Name types.String `tfsdk:"name"`
 
@@ -56,12 +176,24 @@ type InstanceResourceModel struct {
 	// PrivateIp The private IPv4 IP-Address (IPv4 address).
 	PrivateIp types.String `tfsdk:"private_ip"`
 
+	// PowerState The target power state of the instance.
+	PowerState types.String `tfsdk:"power_state"`
+
+	// ProvisioningRetries How often an instance in error state is deleted and created again.
+	ProvisioningRetries types.Int64 `tfsdk:"provisioning_retries"`
+
+	// ProvisioningRetryDelay The time to wait before an instance in error state is created again.
+	ProvisioningRetryDelay types.String `tfsdk:"provisioning_retry_delay"`
+
 	// PublicIp The public IPv4 IP-Address (IPv4 address).
 	PublicIp types.String `tfsdk:"public_ip"`
 
 	// Region The region identifier.
 	Region types.String `tfsdk:"region"`
 
+	// RegionFallbacks The regions tried in order if the instance cannot be created in the region due to missing capacity.
+	RegionFallbacks types.List `tfsdk:"region_fallbacks"`
+
 	// SecurityGroupIds The security groups of the instance.
 	SecurityGroupIds types.Set `tfsdk:"security_group_ids"`
 
@@ -71,14 +203,26 @@ type InstanceResourceModel struct {
 	// Status The instance status
 	Status types.String `tfsdk:"status"`
 
+	// StopForDiskResize Flag to stop a running instance to resize its disk.
+	StopForDiskResize types.Bool `tfsdk:"stop_for_disk_resize"`
+
 	// Type The instance type identifier.
 	Type types.String `tfsdk:"type"`
 
+	// TypeFallbacks The instance types tried in order if the instance cannot be created with the type due to missing capacity.
+	TypeFallbacks types.List `tfsdk:"type_fallbacks"`
+
 	UpdatedAt types.String `tfsdk:"updated_at"`
 
 	// VolumeIds The volumes of the instance
 	VolumeIds types.Set `tfsdk:"volume_ids"`
 
+	// WaitFor Option to wait until a port of the instance is reachable after it is created.
+	WaitFor *InstanceWaitForModel `tfsdk:"wait_for"`
+
+	// WaitForCloudInit Option to wait until cloud-init is done after the instance is created.
+	WaitForCloudInit *InstanceWaitForCloudInitModel `tfsdk:"wait_for_cloud_init"`
+
 	// FloatingIp The floating IP of the instance.
 	FloatingIpId types.String `tfsdk:"floating_ip_id"`
 
@@ -91,12 +235,42 @@ type InstanceResourceModel struct {
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
+// StartupScript returns the configured startup script or null if there is no metadata.
+func (data *InstanceResourceModel) StartupScript() types.String {
+	if data.Metadata == nil {
+		return types.StringNull()
+	}
+
+	return data.Metadata.StartupScript
+}
+
+// ProbeAddress returns the public IP of the instance if it has one and the private IP otherwise.
+func (data *InstanceResourceModel) ProbeAddress() string {
+	if address := data.PublicIp.ValueString(); address != "" {
+		return address
+	}
+
+	return data.PrivateIp.ValueString()
+}
+
 func (data *InstanceResourceModel) PopulateFromClientResponse(ctx context.Context, instance *genesiscloud.Instance) (diag diag.Diagnostics) {
 	data.Id = types.StringValue(instance.Id)
 	data.Name = types.StringValue(instance.Name)
 	data.Hostname = types.StringValue(instance.Hostname)
 	data.DnsName = types.StringValue(instance.DnsName)
-	data.Type = types.StringValue(string(instance.Type))
+
+	var typeFallbacks []string
+	if !data.TypeFallbacks.IsNull() && !data.TypeFallbacks.IsUnknown() {
+		diag = data.TypeFallbacks.ElementsAs(ctx, &typeFallbacks, false)
+		if diag.HasError() {
+			return
+		}
+	}
+
+	// A fallback type does not cause a diff, the actual type is reported as effective_type
+	data.Type = preferredValue(data.Type, typeFallbacks, string(instance.Type))
+	data.EffectiveType = types.StringValue(string(instance.Type))
+
 	data.ImageId = types.StringValue(instance.Image.Id)
 
 	volumeIds := make([]string, 0) // volumes do NOT support NULL
@@ -148,7 +322,17 @@ func (data *InstanceResourceModel) PopulateFromClientResponse(ctx context.Contex
 		data.DiskSize = types.Int64Value(int64(*instance.DiskSize))
 	}
 
-	data.Region = types.StringValue(string(instance.Region))
+	var regionFallbacks []string
+	if !data.RegionFallbacks.IsNull() && !data.RegionFallbacks.IsUnknown() {
+		diag = data.RegionFallbacks.ElementsAs(ctx, &regionFallbacks, false)
+		if diag.HasError() {
+			return
+		}
+	}
+
+	// A fallback region does not cause a diff, the actual region is reported as effective_region
+	data.Region = preferredValue(data.Region, regionFallbacks, string(instance.Region))
+	data.EffectiveRegion = types.StringValue(string(instance.Region))
 	data.Status = types.StringValue(string(instance.Status))
 	data.CreatedAt = types.StringValue(instance.CreatedAt.Format(time.RFC3339))
 	data.UpdatedAt = types.StringValue(instance.UpdatedAt.Format(time.RFC3339))
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestInstanceFinalSnapshotModelSnapshotName(t *testing.T) {
+	now := time.Date(2024, 5, 17, 12, 30, 45, 0, time.FixedZone("CEST", 2*60*60))
+
+	testCases := map[string]struct {
+		nameTemplate types.String
+		expected     string
+	}{
+		"default name": {
+			nameTemplate: types.StringNull(),
+			expected:     "web-final-20240517-103045",
+		},
+		"unknown name": {
+			nameTemplate: types.StringUnknown(),
+			expected:     "web-final-20240517-103045",
+		},
+		"explicit name": {
+			nameTemplate: types.StringValue("web-backup"),
+			expected:     "web-backup",
+		},
+		"name prefix": {
+			nameTemplate: types.StringValue("backup-{name}"),
+			expected:     "backup-web",
+		},
+		"all placeholders": {
+			nameTemplate: types.StringValue("{name}-{id}-{timestamp}"),
+			expected:     "web-instance-1-20240517-103045",
+		},
+		"repeated placeholder": {
+			nameTemplate: types.StringValue("{name}-{name}"),
+			expected:     "web-web",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := InstanceFinalSnapshotModel{NameTemplate: testCase.nameTemplate}
+
+			if actual := data.SnapshotName("web", "instance-1", now); actual != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultWaitForPort is the port probed if none is configured.
+	defaultWaitForPort = 22
+
+	// defaultWaitForTimeout is the time the port is probed for if no timeout is configured.
+	defaultWaitForTimeout = "5m"
+
+	// waitForDialTimeout bounds a single connection attempt or banner read.
+	waitForDialTimeout = 10 * time.Second
+
+	// sshBannerMaxLines is the number of lines a server may send before its SSH banner (RFC 4253, section 4.2).
+	sshBannerMaxLines = 20
+)
+
+var errNoSSHBanner = errors.New("the server did not send an SSH banner")
+
+// waitForPort probes the TCP port of the address until a connection succeeds
+// and, if sshBanner is set, the server sends an SSH banner. It returns the last
+// error once ctx is done.
+func waitForPort(ctx context.Context, address string, port int, sshBanner bool, interval time.Duration) error {
+	target := net.JoinHostPort(address, strconv.Itoa(port))
+
+	for attempt := 1; ; attempt++ {
+		err := probePort(ctx, target, sshBanner)
+		if err == nil {
+			tflog.Debug(ctx, "instance port is reachable", map[string]interface{}{"address": target, "attempt": attempt})
+			return nil
+		}
+
+		tflog.Trace(ctx, "instance port is not reachable yet", map[string]interface{}{"address": target, "attempt": attempt, "error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s is not reachable: %w (last error: %s)", target, ctx.Err(), err)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func probePort(ctx context.Context, target string, sshBanner bool) error {
+	dialer := net.Dialer{Timeout: waitForDialTimeout}
+
+	conn, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if !sshBanner {
+		return nil
+	}
+
+	err = conn.SetReadDeadline(time.Now().Add(waitForDialTimeout))
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	for i := 0; i < sshBannerMaxLines; i++ {
+		line, err := reader.ReadString('\n')
+		if strings.HasPrefix(line, "SSH-") {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s", errNoSSHBanner, err)
+		}
+	}
+
+	return errNoSSHBanner
+}
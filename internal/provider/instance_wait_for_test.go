@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// listenWithGreeting starts a TCP listener on 127.0.0.1 which writes the greeting to every connection.
+func listenWithGreeting(t *testing.T, greeting string) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %s", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write([]byte(greeting))
+			conn.Close()
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+// unusedPort returns a port on 127.0.0.1 nothing is listening on.
+func unusedPort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %s", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestWaitForPort(t *testing.T) {
+	testCases := map[string]struct {
+		greeting  string
+		sshBanner bool
+		reachable bool
+	}{
+		"port": {
+			greeting:  "",
+			reachable: true,
+		},
+		"ssh banner": {
+			greeting:  "SSH-2.0-OpenSSH_9.6\r\n",
+			sshBanner: true,
+			reachable: true,
+		},
+		"ssh banner after other lines": {
+			greeting:  "Welcome\r\nSSH-2.0-OpenSSH_9.6\r\n",
+			sshBanner: true,
+			reachable: true,
+		},
+		"no ssh banner": {
+			greeting:  "HTTP/1.1 400 Bad Request\r\n\r\n",
+			sshBanner: true,
+			reachable: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			port := listenWithGreeting(t, testCase.greeting)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			err := waitForPort(ctx, "127.0.0.1", port, testCase.sshBanner, 10*time.Millisecond)
+			if testCase.reachable && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.reachable {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("expected a deadline error, got %s", err)
+				}
+			}
+		})
+	}
+}
+
+func TestWaitForPortClosed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := waitForPort(ctx, "127.0.0.1", unusedPort(t), false, 10*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+}
+
+func TestWaitForPortDelayed(t *testing.T) {
+	port := unusedPort(t)
+
+	listeners := make(chan net.Listener, 1)
+	defer func() {
+		if listener := <-listeners; listener != nil {
+			listener.Close()
+		}
+	}()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+
+		listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+		listeners <- listener
+		if err != nil {
+			return
+		}
+
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := waitForPort(ctx, "127.0.0.1", port, true, 10*time.Millisecond)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"sync"
+)
+
+// KeyedMutex provides one mutex per key, e.g. to serialize read-modify-write
+// cycles on the same remote object within a single provider instance.
+type KeyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// Lock acquires the mutex for the given key and returns the matching unlock function.
+func (m *KeyedMutex) Lock(key string) func() {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := m.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.locks[key] = lock
+	}
+	m.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+type SecurityGroupRulesMode int
+
+const (
+	// SecurityGroupRulesInline means the rules are managed by the `rules` attribute of `genesiscloud_security_group`.
+	SecurityGroupRulesInline SecurityGroupRulesMode = iota + 1
+
+	// SecurityGroupRulesStandalone means the rules are managed by `genesiscloud_security_group_rule` resources.
+	SecurityGroupRulesStandalone
+)
+
+// SecurityGroupRulesRegistry records how the rules of each security group are
+// managed, so that mixing both styles on the same group can be detected. The
+// detection is best effort: it only sees the resources planned by this provider
+// instance and no security groups whose id is unknown, e.g. created in the same
+// apply. It must not be relied on for destructive decisions.
+type SecurityGroupRulesRegistry struct {
+	mu    sync.Mutex
+	modes map[string]map[SecurityGroupRulesMode]bool
+}
+
+// Register records the mode for the security group and reports whether the
+// group is already managed in another mode.
+func (r *SecurityGroupRulesRegistry) Register(securityGroupId string, mode SecurityGroupRulesMode) (conflict bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.modes == nil {
+		r.modes = make(map[string]map[SecurityGroupRulesMode]bool)
+	}
+	if r.modes[securityGroupId] == nil {
+		r.modes[securityGroupId] = make(map[SecurityGroupRulesMode]bool)
+	}
+	r.modes[securityGroupId][mode] = true
+
+	return len(r.modes[securityGroupId]) > 1
+}
@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestSecurityGroupRulesRegistry(t *testing.T) {
+	var registry SecurityGroupRulesRegistry
+
+	if registry.Register("sg-1", SecurityGroupRulesStandalone) {
+		t.Errorf("expected no conflict for the first mode")
+	}
+
+	if registry.Register("sg-1", SecurityGroupRulesStandalone) || registry.Register("sg-2", SecurityGroupRulesInline) {
+		t.Errorf("expected no conflict for the same mode or another security group")
+	}
+
+	if !registry.Register("sg-1", SecurityGroupRulesInline) {
+		t.Errorf("expected a conflict for the second mode")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/providerenhancer"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/timedurationvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -20,7 +21,8 @@ import (
 
 // Ensure GenesisCloudProvider satisfies various provider interfaces.
 var (
-	_ provider.Provider = &GenesisCloudProvider{}
+	_ provider.Provider                       = &GenesisCloudProvider{}
+	_ provider.ProviderWithEphemeralResources = &GenesisCloudProvider{}
 )
 
 // GenesisCloudProvider defines the provider implementation.
@@ -176,18 +178,32 @@ func (p *GenesisCloudProvider) Resources(ctx context.Context) []func() resource.
 	return []func() resource.Resource{
 		NewInstanceResource,
 		NewInstanceStatusResource,
+		NewInstanceScheduleResource,
 		NewSSHKeyResource,
+		NewSSHKeySetResource,
 		NewFloatingIPResource,
 		NewVolumeResource,
 		NewFilesystemResource,
 		NewSecurityGroupResource,
+		NewSecurityGroupRuleResource,
+		NewDefaultSecurityGroupResource,
 		NewSnapshotResource,
+		NewSnapshotPolicyResource,
 	}
 }
 
 func (p *GenesisCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewFilesystemMountConfigDataSource,
 		NewImagesDataSource,
+		NewSnapshotDataSource,
+		NewSnapshotsDataSource,
+	}
+}
+
+func (p *GenesisCloudProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSSHKeyPairEphemeralResource,
 	}
 }
 
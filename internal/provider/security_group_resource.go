@@ -2,20 +2,25 @@ package provider
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/cidrvalidator"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -25,6 +30,7 @@ var (
 	_ resource.ResourceWithConfigure        = &SecurityGroupResource{}
 	_ resource.ResourceWithImportState      = &SecurityGroupResource{}
 	_ resource.ResourceWithConfigValidators = &SecurityGroupResource{}
+	_ resource.ResourceWithModifyPlan       = &SecurityGroupResource{}
 )
 
 func NewSecurityGroupResource() resource.Resource {
@@ -83,42 +89,20 @@ func (r *SecurityGroupResource) Schema(ctx context.Context, req resource.SchemaR
 					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
 				},
 			}),
-			"rules": schema.ListNestedAttribute{
-				Required: true,
-				NestedObject: schema.NestedAttributeObject{
-					Attributes: map[string]schema.Attribute{
-						"direction": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-							MarkdownDescription: "The direction of the rule.",
-							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.OneOf(sliceStringify(genesiscloud.AllSecurityGroupRuleDirections)...),
-							},
-						}),
-						"port_range_max": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
-							MarkdownDescription: "The maximum port number of the rule.",
-							Optional:            true,
-							Validators: []validator.Int64{
-								int64validator.Between(1, 65535),
-							},
-						}),
-						"port_range_min": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
-							MarkdownDescription: "The minimum port number of the rule.",
-							Optional:            true,
-							Validators: []validator.Int64{
-								int64validator.Between(1, 65535),
-							},
-						}),
-						"protocol": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-							MarkdownDescription: "The protocol of the rule.",
-							Required:            true,
-							Validators: []validator.String{
-								stringvalidator.OneOf(sliceStringify(genesiscloud.AllSecurityGroupRuleProtocols)...),
-							},
-						}),
-					},
+			"rules": schema.SetNestedAttribute{
+				MarkdownDescription: "The security group rules. If not provided, the rules are left to `genesiscloud_security_group_rule` resources. " +
+					"Managing the rules of a security group both inline and with `genesiscloud_security_group_rule` resources is not supported. " +
+					"It is detected on plan as far as possible, but not for a security group created in the same apply. " +
+					"Rules are compared in their canonical form: the protocol is case-insensitive, the port range `1`-`65535` is the same as no port range and duplicate rules are merged. " +
+					"Rules must not overlap.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(), // if unset, expect no changes
 				},
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				NestedObject: securityGroupRuleNestedObject(ctx),
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
 				},
 			},
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
@@ -135,9 +119,85 @@ func (r *SecurityGroupResource) Schema(ctx context.Context, req resource.SchemaR
 	}
 }
 
+// securityGroupRuleNestedObject returns the schema of a single security group rule.
+func securityGroupRuleNestedObject(ctx context.Context) schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"direction": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The direction of the rule.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(sliceStringify(genesiscloud.AllSecurityGroupRuleDirections)...),
+				},
+			}),
+			"port_range_max": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The maximum port number of the rule. Only supported for the protocols `tcp` and `udp`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			}),
+			"port_range_min": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The minimum port number of the rule. Only supported for the protocols `tcp` and `udp`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			}),
+			"protocol": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The protocol of the rule.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(sliceStringify(genesiscloud.AllSecurityGroupRuleProtocols)...),
+				},
+			}),
+			"remote_cidr": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The IPv4 or IPv6 network the rule applies to, e.g. `203.0.113.0/24`. If neither `remote_cidr` nor `remote_security_group_id` is provided, the rule applies to any address.",
+				Optional:            true,
+				Validators: []validator.String{
+					cidrvalidator.Network(),
+				},
+			}),
+			"remote_security_group_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the security group whose members the rule applies to.",
+				Optional:            true,
+			}),
+		},
+	}
+}
+
 func (r *SecurityGroupResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
-		// Improvement: Add additional rule validation
+		securityGroupRulesValidator{
+			attributePath: path.Root("rules"),
+		},
+	}
+}
+
+func (r *SecurityGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or if the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var securityGroupId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("id"), &securityGroupId)...)
+
+	var configRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &configRules)...)
+
+	// The conflict is detected best effort, see SecurityGroupRulesRegistry
+	if resp.Diagnostics.HasError() || securityGroupId.IsUnknown() || configRules.IsNull() {
+		return
+	}
+
+	if r.client.SecurityGroupRules.Register(securityGroupId.ValueString(), SecurityGroupRulesInline) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rules"),
+			"Conflicting Security Group Rules",
+			fmt.Sprintf("The rules of the security group with id %q are managed both inline and with genesiscloud_security_group_rule resources. "+
+				"Either remove the `rules` attribute or the genesiscloud_security_group_rule resources.", securityGroupId.ValueString()),
+		)
 	}
 }
 
@@ -163,25 +223,14 @@ func (r *SecurityGroupResource) Create(ctx context.Context, req resource.CreateR
 	body.Name = data.Name.ValueString()
 	body.Region = genesiscloud.Region(data.Region.ValueString())
 
-	for _, rule := range data.Rules {
-		var portRangeMax, portRangeMin *int
-
-		if !rule.PortRangeMax.IsNull() && !rule.PortRangeMax.IsUnknown() {
-			portRangeMax = pointer(int(rule.PortRangeMax.ValueInt64()))
-		}
-
-		if !rule.PortRangeMin.IsNull() && !rule.PortRangeMin.IsUnknown() {
-			portRangeMin = pointer(int(rule.PortRangeMin.ValueInt64()))
-		}
-
-		body.Rules = append(body.Rules, genesiscloud.SecurityGroupRule{
-			Direction:    genesiscloud.SecurityGroupRuleDirection(rule.Direction.ValueString()),
-			PortRangeMax: portRangeMax,
-			PortRangeMin: portRangeMin,
-			Protocol:     genesiscloud.SecurityGroupRuleProtocol(rule.Protocol.ValueString()),
-		})
+	rules, diag := data.RulesToClientRequest(ctx)
+	if diag.HasError() {
+		resp.Diagnostics.Append(diag...)
+		return
 	}
 
+	body.Rules = rules
+
 	response, err := r.client.CreateSecurityGroupWithResponse(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("create security_group", err))
@@ -324,30 +373,29 @@ func (r *SecurityGroupResource) Update(ctx context.Context, req resource.UpdateR
 
 	body.Description = pointer(data.Description.ValueString())
 	body.Name = pointer(data.Name.ValueString())
-	rules := make([]genesiscloud.SecurityGroupRule, 0)
 
-	for _, rule := range data.Rules {
-		var portRangeMax, portRangeMin *int
+	securityGroupId := data.Id.ValueString()
 
-		if !rule.PortRangeMax.IsNull() && !rule.PortRangeMax.IsUnknown() {
-			portRangeMax = pointer(int(rule.PortRangeMax.ValueInt64()))
-		}
+	var configRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rules"), &configRules)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		if !rule.PortRangeMin.IsNull() && !rule.PortRangeMin.IsUnknown() {
-			portRangeMin = pointer(int(rule.PortRangeMin.ValueInt64()))
+	// Only take over the rules if they are managed inline, otherwise they
+	// belong to genesiscloud_security_group_rule resources.
+	if !configRules.IsNull() {
+		rules, diag := data.RulesToClientRequest(ctx)
+		if diag.HasError() {
+			resp.Diagnostics.Append(diag...)
+			return
 		}
 
-		rules = append(rules, genesiscloud.SecurityGroupRule{
-			Direction:    genesiscloud.SecurityGroupRuleDirection(rule.Direction.ValueString()),
-			PortRangeMax: portRangeMax,
-			PortRangeMin: portRangeMin,
-			Protocol:     genesiscloud.SecurityGroupRuleProtocol(rule.Protocol.ValueString()),
-		})
+		body.Rules = pointer(rules)
 	}
 
-	body.Rules = pointer(rules)
-
-	securityGroupId := data.Id.ValueString()
+	unlock := r.client.SecurityGroupLocks.Lock(securityGroupId)
+	defer unlock()
 
 	response, err := r.client.UpdateSecurityGroupWithResponse(ctx, securityGroupId, body)
 	if err != nil {
@@ -481,3 +529,81 @@ func (r *SecurityGroupResource) Delete(ctx context.Context, req resource.DeleteR
 func (r *SecurityGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
+
+var _ resource.ConfigValidator = securityGroupRulesValidator{}
+
+// securityGroupRulesValidator validates each of the configured security group rules
+// and ensures that no two rules overlap.
+type securityGroupRulesValidator struct {
+	// attributePath is the path of the rules set attribute.
+	attributePath path.Path
+}
+
+func (v securityGroupRulesValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v securityGroupRulesValidator) MarkdownDescription(_ context.Context) string {
+	return "Each rule must be valid on its own and must not overlap with another rule."
+}
+
+func (v securityGroupRulesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.attributePath, &rules)...)
+	if resp.Diagnostics.HasError() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	type knownRule struct {
+		path path.Path
+		rule genesiscloud.SecurityGroupRule
+	}
+
+	var knownRules []knownRule
+
+	for _, element := range rules.Elements() {
+		object, ok := element.(types.Object)
+		if !ok || object.IsNull() || object.IsUnknown() {
+			continue
+		}
+
+		var rule SecurityGroupRuleModel
+		resp.Diagnostics.Append(object.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		rulePath := v.attributePath.AtSetValue(element)
+
+		diag := rule.Validate(rulePath)
+		resp.Diagnostics.Append(diag...)
+		if diag.HasError() || !rule.IsKnown() {
+			continue
+		}
+
+		knownRules = append(knownRules, knownRule{
+			path: rulePath,
+			rule: normalizeSecurityGroupRule(rule.ToClientRequest()),
+		})
+	}
+
+	for i := range knownRules {
+		for j := i + 1; j < len(knownRules); j++ {
+			a, b := knownRules[i], knownRules[j]
+
+			// Rules which only differ in notation are merged
+			if securityGroupRuleKey(a.rule) == securityGroupRuleKey(b.rule) {
+				continue
+			}
+
+			if securityGroupRulesOverlap(a.rule, b.rule) {
+				resp.Diagnostics.AddAttributeError(
+					b.path,
+					"Overlapping Security Group Rules",
+					fmt.Sprintf("The rule %s overlaps with the rule %s. Merge both rules or narrow down their port ranges or remotes.",
+						securityGroupRuleId("", b.rule)[1:], securityGroupRuleId("", a.rule)[1:]),
+				)
+			}
+		}
+	}
+}
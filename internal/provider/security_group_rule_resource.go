@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/cidrvalidator"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                     = &SecurityGroupRuleResource{}
+	_ resource.ResourceWithConfigure        = &SecurityGroupRuleResource{}
+	_ resource.ResourceWithImportState      = &SecurityGroupRuleResource{}
+	_ resource.ResourceWithModifyPlan       = &SecurityGroupRuleResource{}
+	_ resource.ResourceWithConfigValidators = &SecurityGroupRuleResource{}
+)
+
+func NewSecurityGroupRuleResource() resource.Resource {
+	return &SecurityGroupRuleResource{}
+}
+
+// SecurityGroupRuleResource defines the resource implementation.
+type SecurityGroupRuleResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *SecurityGroupRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_security_group_rule"
+}
+
+func (r *SecurityGroupRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Security group rule resource. Adds a single rule to an existing security group whose `rules` are not managed inline.",
+
+		Attributes: map[string]schema.Attribute{
+			"direction": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The direction of the rule.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(sliceStringify(genesiscloud.AllSecurityGroupRuleDirections)...),
+				},
+			}),
+			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The composite ID of the security group rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"port_range_max": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The maximum port number of the rule. Only supported for the protocols `tcp` and `udp`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			}),
+			"port_range_min": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The minimum port number of the rule. Only supported for the protocols `tcp` and `udp`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			}),
+			"protocol": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The protocol of the rule.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(sliceStringify(genesiscloud.AllSecurityGroupRuleProtocols)...),
+				},
+			}),
+			"remote_cidr": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The IPv4 or IPv6 network the rule applies to, e.g. `203.0.113.0/24`. If neither `remote_cidr` nor `remote_security_group_id` is provided, the rule applies to any address.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					cidrvalidator.Network(),
+				},
+			}),
+			"remote_security_group_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the security group whose members the rule applies to.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+			"security_group_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the security group the rule belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+
+			// Internal
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *SecurityGroupRuleResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("remote_cidr"),
+			path.MatchRoot("remote_security_group_id"),
+		),
+		securityGroupRuleValidator{},
+	}
+}
+
+func (r *SecurityGroupRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or if the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var securityGroupId types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("security_group_id"), &securityGroupId)...)
+	// The conflict is detected best effort, see SecurityGroupRulesRegistry
+	if resp.Diagnostics.HasError() || securityGroupId.IsUnknown() {
+		return
+	}
+
+	if r.client.SecurityGroupRules.Register(securityGroupId.ValueString(), SecurityGroupRulesStandalone) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("security_group_id"),
+			"Conflicting Security Group Rules",
+			fmt.Sprintf("The rules of the security group with id %q are managed both inline and with genesiscloud_security_group_rule resources. "+
+				"Either remove the `rules` attribute of the security group or the genesiscloud_security_group_rule resources.", securityGroupId.ValueString()),
+		)
+	}
+}
+
+func (r *SecurityGroupRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SecurityGroupRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	securityGroupId := data.SecurityGroupId.ValueString()
+	rule := data.ToClientRequest()
+
+	resp.Diagnostics.Append(r.updateRules(ctx, securityGroupId, "create security_group_rule", func(rules []genesiscloud.SecurityGroupRule) ([]genesiscloud.SecurityGroupRule, error) {
+		for _, existing := range rules {
+			if securityGroupRuleEqual(existing, rule) {
+				return nil, fmt.Errorf("the rule %q already exists, import it instead", securityGroupRuleId(securityGroupId, rule))
+			}
+
+			if securityGroupRulesOverlap(normalizeSecurityGroupRule(existing), normalizeSecurityGroupRule(rule)) {
+				return nil, fmt.Errorf("the rule %q overlaps with the existing rule %q", securityGroupRuleId(securityGroupId, rule), securityGroupRuleId(securityGroupId, existing))
+			}
+		}
+
+		return append(rules, rule), nil
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroupId, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a security group rule resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecurityGroupRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SecurityGroupRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	securityGroupId := data.SecurityGroupId.ValueString()
+	rule := data.ToClientRequest()
+
+	response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", generateErrorMessage("read security_group_rule", err))
+		return
+	}
+
+	if response.StatusCode() == 404 {
+		tflog.Trace(ctx, "security group of the rule is gone, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	securityGroupResponse := response.JSON200
+	if securityGroupResponse == nil {
+		resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("read security_group_rule", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	found := false
+	for _, existing := range securityGroupResponse.SecurityGroup.Rules {
+		if securityGroupRuleEqual(existing, rule) {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		tflog.Trace(ctx, "security group rule is gone, removing from state")
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The remote rule is semantically equal, keep the notation of the prior state
+
+	tflog.Trace(ctx, "read a security group rule resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecurityGroupRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SecurityGroupRuleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// all rule attributes require replacement, only the timeouts can change in place
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecurityGroupRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SecurityGroupRuleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	securityGroupId := data.SecurityGroupId.ValueString()
+	rule := data.ToClientRequest()
+
+	resp.Diagnostics.Append(r.updateRules(ctx, securityGroupId, "delete security_group_rule", func(rules []genesiscloud.SecurityGroupRule) ([]genesiscloud.SecurityGroupRule, error) {
+		remaining := make([]genesiscloud.SecurityGroupRule, 0, len(rules))
+		for _, existing := range rules {
+			if !securityGroupRuleEqual(existing, rule) {
+				remaining = append(remaining, existing)
+			}
+		}
+
+		return remaining, nil
+	})...)
+}
+
+func (r *SecurityGroupRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	securityGroupId, rule, err := parseSecurityGroupRuleId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	var data SecurityGroupRuleResourceModel
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, securityGroupId, &rule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), data.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("security_group_id"), data.SecurityGroupId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("direction"), data.Direction)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("protocol"), data.Protocol)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port_range_min"), data.PortRangeMin)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("port_range_max"), data.PortRangeMax)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_cidr"), data.RemoteCidr)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("remote_security_group_id"), data.RemoteSecurityGroupId)...)
+}
+
+// updateRules performs a read-modify-write cycle on the rules of the security
+// group and waits until the change has been applied. Concurrent cycles on the
+// same security group are serialized.
+func (r *SecurityGroupRuleResource) updateRules(ctx context.Context, securityGroupId string, verb string,
+	mutate func(rules []genesiscloud.SecurityGroupRule) ([]genesiscloud.SecurityGroupRule, error)) (diags diag.Diagnostics) {
+
+	unlock := r.client.SecurityGroupLocks.Lock(securityGroupId)
+	defer unlock()
+
+	response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return
+	}
+
+	securityGroupResponse := response.JSON200
+	if securityGroupResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	rules, err := mutate(securityGroupResponse.SecurityGroup.Rules)
+	if err != nil {
+		diags.AddError("Invalid Security Group Rule", generateErrorMessage(verb, err))
+		return
+	}
+
+	body := genesiscloud.UpdateSecurityGroupJSONRequestBody{}
+	body.Rules = pointer(rules)
+
+	updateResponse, err := r.client.UpdateSecurityGroupWithResponse(ctx, securityGroupId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return
+	}
+
+	if updateResponse.JSON200 == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         updateResponse.Body,
+			HTTPResponse: updateResponse.HTTPResponse,
+			Error:        updateResponse.JSONDefault,
+		}))
+		return
+	}
+
+	for {
+		err := r.client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling security_group", err))
+			return
+		}
+
+		tflog.Trace(ctx, "polling a security group resource")
+
+		response, err := r.client.GetSecurityGroupWithResponse(ctx, securityGroupId)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("polling security_group", err))
+			return
+		}
+
+		securityGroupResponse := response.JSON200
+		if securityGroupResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("polling security_group", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		status := securityGroupResponse.SecurityGroup.Status
+		if status == genesiscloud.SecurityGroupStatusError {
+			diags.AddError("Provisioning Error", generateErrorMessage("polling security_group", ErrResourceInErrorState))
+			return
+		}
+
+		if status == genesiscloud.SecurityGroupStatusCreated {
+			return
+		}
+	}
+}
+
+var _ resource.ConfigValidator = securityGroupRuleValidator{}
+
+// securityGroupRuleValidator validates the rule attributes in combination.
+type securityGroupRuleValidator struct {
+}
+
+func (v securityGroupRuleValidator) Description(ctx context.Context) string {
+	return v.MarkdownDescription(ctx)
+}
+
+func (v securityGroupRuleValidator) MarkdownDescription(_ context.Context) string {
+	return "The port range must only be set for the protocols `tcp` and `udp` and the minimum port must not be greater than the maximum port."
+}
+
+func (v securityGroupRuleValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SecurityGroupRuleResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule := SecurityGroupRuleModel{
+		Direction:    data.Direction,
+		PortRangeMax: data.PortRangeMax,
+		PortRangeMin: data.PortRangeMin,
+		Protocol:     data.Protocol,
+		// remote_cidr and remote_security_group_id are covered by resourcevalidator.Conflicting
+		RemoteCidr:            types.StringNull(),
+		RemoteSecurityGroupId: types.StringNull(),
+	}
+
+	resp.Diagnostics.Append(rule.Validate(path.Empty())...)
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSecurityGroupRuleResourceConfig(portRangeMin, portRangeMax int) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_security_group" "test" {
+  name   = "test"
+  region = "NORD-NO-KRS-1"
+}
+
+resource "genesiscloud_security_group_rule" "test" {
+  security_group_id = genesiscloud_security_group.test.id
+  direction         = "ingress"
+  protocol          = "tcp"
+  port_range_min    = %[1]d
+  port_range_max    = %[2]d
+}
+`, portRangeMin, portRangeMax)
+}
+
+func TestAccSecurityGroupRuleResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSecurityGroupRuleResourceConfig(443, 443),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "direction", "ingress"),
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "protocol", "tcp"),
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "port_range_min", "443"),
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "port_range_max", "443"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "genesiscloud_security_group_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Replace and Read testing
+			{
+				Config: providerConfig + testAccSecurityGroupRuleResourceConfig(8000, 8080),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "port_range_min", "8000"),
+					resource.TestCheckResourceAttr("genesiscloud_security_group_rule.test", "port_range_max", "8080"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SecurityGroupRuleResourceModel struct {
+	// Id The composite ID of the security group rule.
+	Id types.String `tfsdk:"id"`
+
+	// SecurityGroupId The id of the security group the rule belongs to.
+	SecurityGroupId types.String `tfsdk:"security_group_id"`
+
+	// Direction The direction of the rule.
+	Direction types.String `tfsdk:"direction"`
+
+	// PortRangeMax The maximum port number of the rule.
+	PortRangeMax types.Int64 `tfsdk:"port_range_max"`
+
+	// PortRangeMin The minimum port number of the rule.
+	PortRangeMin types.Int64 `tfsdk:"port_range_min"`
+
+	// Protocol The protocol of the rule.
+	Protocol types.String `tfsdk:"protocol"`
+
+	// RemoteCidr The IPv4 or IPv6 network the rule applies to.
+	RemoteCidr types.String `tfsdk:"remote_cidr"`
+
+	// RemoteSecurityGroupId The id of the security group whose members the rule applies to.
+	RemoteSecurityGroupId types.String `tfsdk:"remote_security_group_id"`
+
+	// Internal
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (data *SecurityGroupRuleResourceModel) ToClientRequest() genesiscloud.SecurityGroupRule {
+	rule := SecurityGroupRuleModel{
+		Direction:             data.Direction,
+		PortRangeMax:          data.PortRangeMax,
+		PortRangeMin:          data.PortRangeMin,
+		Protocol:              data.Protocol,
+		RemoteCidr:            data.RemoteCidr,
+		RemoteSecurityGroupId: data.RemoteSecurityGroupId,
+	}
+
+	return rule.ToClientRequest()
+}
+
+func (data *SecurityGroupRuleResourceModel) PopulateFromClientResponse(ctx context.Context, securityGroupId string, securityGroupRule *genesiscloud.SecurityGroupRule) (diag diag.Diagnostics) {
+	rule := SecurityGroupRuleModel{}
+
+	diag = rule.PopulateFromClientResponse(ctx, securityGroupRule)
+	if diag.HasError() {
+		return
+	}
+
+	data.Id = types.StringValue(securityGroupRuleId(securityGroupId, *securityGroupRule))
+	data.SecurityGroupId = types.StringValue(securityGroupId)
+	data.Direction = rule.Direction
+	data.PortRangeMax = rule.PortRangeMax
+	data.PortRangeMin = rule.PortRangeMin
+	data.Protocol = rule.Protocol
+	data.RemoteCidr = rule.RemoteCidr
+	data.RemoteSecurityGroupId = rule.RemoteSecurityGroupId
+
+	return
+}
+
+// securityGroupRuleId returns the composite ID of a rule in the format
+// `<security_group_id>/<direction>/<protocol>/<port_range_min>-<port_range_max>@<remote>`.
+// The port range is omitted if the rule has none. The remote is either the
+// remote CIDR or the remote security group id and is omitted if the rule has none.
+func securityGroupRuleId(securityGroupId string, rule genesiscloud.SecurityGroupRule) string {
+	id := fmt.Sprintf("%s/%s/%s", securityGroupId, rule.Direction, rule.Protocol)
+
+	if rule.PortRangeMin != nil || rule.PortRangeMax != nil {
+		id += "/" + formatPort(rule.PortRangeMin) + "-" + formatPort(rule.PortRangeMax)
+	}
+
+	if rule.RemoteCidr != nil {
+		id += "@" + *rule.RemoteCidr
+	} else if rule.RemoteSecurityGroupId != nil {
+		id += "@" + *rule.RemoteSecurityGroupId
+	}
+
+	return id
+}
+
+// parseSecurityGroupRuleId is the inverse of securityGroupRuleId.
+func parseSecurityGroupRuleId(id string) (securityGroupId string, rule genesiscloud.SecurityGroupRule, err error) {
+	id, remote, found := strings.Cut(id, "@")
+	if found {
+		// CIDRs contain a slash, security group ids do not
+		if _, cidrErr := netip.ParsePrefix(remote); cidrErr == nil {
+			rule.RemoteCidr = pointer(remote)
+		} else if remote != "" && !strings.Contains(remote, "/") {
+			rule.RemoteSecurityGroupId = pointer(remote)
+		} else {
+			err = fmt.Errorf("expected remote CIDR or remote security group id after '@', got %q", remote)
+			return
+		}
+	}
+
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 && len(parts) != 4 {
+		err = fmt.Errorf("expected <security_group_id>/<direction>/<protocol>/<port_range_min>-<port_range_max>[@<remote>], got %q", id)
+		return
+	}
+
+	securityGroupId = parts[0]
+	rule.Direction = genesiscloud.SecurityGroupRuleDirection(parts[1])
+	rule.Protocol = genesiscloud.SecurityGroupRuleProtocol(parts[2])
+
+	if len(parts) == 4 {
+		portRangeMin, portRangeMax, found := strings.Cut(parts[3], "-")
+		if !found {
+			err = fmt.Errorf("expected port range in the format <port_range_min>-<port_range_max>, got %q", parts[3])
+			return
+		}
+
+		rule.PortRangeMin, err = parsePort(portRangeMin)
+		if err != nil {
+			return
+		}
+
+		rule.PortRangeMax, err = parsePort(portRangeMax)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// securityGroupRuleEqual reports whether both rules describe the same traffic.
+func securityGroupRuleEqual(a, b genesiscloud.SecurityGroupRule) bool {
+	return securityGroupRuleKey(normalizeSecurityGroupRule(a)) == securityGroupRuleKey(normalizeSecurityGroupRule(b))
+}
+
+func formatString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func formatPort(port *int) string {
+	if port == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*port)
+}
+
+func parsePort(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port %q: %w", s, err)
+	}
+
+	return &port, nil
+}
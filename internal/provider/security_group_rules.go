@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/genesiscloud/genesiscloud-go"
+)
+
+const (
+	securityGroupRulePortMin = 1
+	securityGroupRulePortMax = 65535
+)
+
+// securityGroupRuleHasPorts reports whether rules of the protocol can be restricted to a port range.
+func securityGroupRuleHasPorts(protocol genesiscloud.SecurityGroupRuleProtocol) bool {
+	protocol = genesiscloud.SecurityGroupRuleProtocol(strings.ToLower(string(protocol)))
+	return protocol == "tcp" || protocol == "udp"
+}
+
+// normalizeSecurityGroupRule returns the canonical form of a rule so that
+// rules which describe the same traffic compare equal:
+//
+//   - Direction and protocol are lowercased.
+//   - Port ranges are dropped for protocols without ports, e.g. icmp.
+//   - A half-open port range is a single port.
+//   - The full port range 1-65535 is the same as no port range.
+//   - The remote CIDR has no host bits set.
+func normalizeSecurityGroupRule(rule genesiscloud.SecurityGroupRule) genesiscloud.SecurityGroupRule {
+	normalized := genesiscloud.SecurityGroupRule{
+		Direction: genesiscloud.SecurityGroupRuleDirection(strings.ToLower(string(rule.Direction))),
+		Protocol:  genesiscloud.SecurityGroupRuleProtocol(strings.ToLower(string(rule.Protocol))),
+	}
+
+	if securityGroupRuleHasPorts(normalized.Protocol) {
+		portRangeMin, portRangeMax := rule.PortRangeMin, rule.PortRangeMax
+		if portRangeMin == nil {
+			portRangeMin = portRangeMax
+		}
+		if portRangeMax == nil {
+			portRangeMax = portRangeMin
+		}
+
+		isFullRange := portRangeMin != nil && portRangeMax != nil &&
+			*portRangeMin == securityGroupRulePortMin && *portRangeMax == securityGroupRulePortMax
+
+		if portRangeMin != nil && !isFullRange {
+			normalized.PortRangeMin = pointer(*portRangeMin)
+			normalized.PortRangeMax = pointer(*portRangeMax)
+		}
+	}
+
+	if rule.RemoteCidr != nil {
+		remoteCidr := *rule.RemoteCidr
+		if prefix, err := netip.ParsePrefix(remoteCidr); err == nil {
+			remoteCidr = prefix.Masked().String()
+		}
+
+		normalized.RemoteCidr = pointer(remoteCidr)
+	}
+
+	if rule.RemoteSecurityGroupId != nil {
+		normalized.RemoteSecurityGroupId = pointer(*rule.RemoteSecurityGroupId)
+	}
+
+	return normalized
+}
+
+// normalizeSecurityGroupRules normalizes every rule, merges duplicates and
+// sorts the result, so the order of the rules does not matter.
+func normalizeSecurityGroupRules(rules []genesiscloud.SecurityGroupRule) []genesiscloud.SecurityGroupRule {
+	normalized := make([]genesiscloud.SecurityGroupRule, 0, len(rules))
+	seen := make(map[string]bool, len(rules))
+
+	for _, rule := range rules {
+		rule = normalizeSecurityGroupRule(rule)
+
+		key := securityGroupRuleKey(rule)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		normalized = append(normalized, rule)
+	}
+
+	sort.Slice(normalized, func(i, j int) bool {
+		return securityGroupRuleKey(normalized[i]) < securityGroupRuleKey(normalized[j])
+	})
+
+	return normalized
+}
+
+// securityGroupRulesEqual reports whether both rule sets allow the same traffic.
+func securityGroupRulesEqual(a, b []genesiscloud.SecurityGroupRule) bool {
+	a, b = normalizeSecurityGroupRules(a), normalizeSecurityGroupRules(b)
+
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if securityGroupRuleKey(a[i]) != securityGroupRuleKey(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// securityGroupRuleKey identifies a rule. Only normalized rules should be compared by key.
+func securityGroupRuleKey(rule genesiscloud.SecurityGroupRule) string {
+	return strings.Join([]string{
+		string(rule.Direction),
+		string(rule.Protocol),
+		formatPort(rule.PortRangeMin),
+		formatPort(rule.PortRangeMax),
+		formatString(rule.RemoteCidr),
+		formatString(rule.RemoteSecurityGroupId),
+	}, "|")
+}
+
+// securityGroupRulesOverlap reports whether some traffic is matched by both
+// rules. Both rules have to be normalized.
+func securityGroupRulesOverlap(a, b genesiscloud.SecurityGroupRule) bool {
+	if a.Direction != b.Direction {
+		return false
+	}
+
+	if a.Protocol != b.Protocol && a.Protocol != "all" && b.Protocol != "all" {
+		return false
+	}
+
+	aMin, aMax := securityGroupRulePortRange(a)
+	bMin, bMax := securityGroupRulePortRange(b)
+	if max(aMin, bMin) > min(aMax, bMax) {
+		return false
+	}
+
+	return securityGroupRuleRemotesOverlap(a, b)
+}
+
+func securityGroupRulePortRange(rule genesiscloud.SecurityGroupRule) (int, int) {
+	if rule.PortRangeMin == nil || rule.PortRangeMax == nil {
+		return securityGroupRulePortMin, securityGroupRulePortMax
+	}
+
+	return *rule.PortRangeMin, *rule.PortRangeMax
+}
+
+func securityGroupRuleRemotesOverlap(a, b genesiscloud.SecurityGroupRule) bool {
+	aAny := a.RemoteCidr == nil && a.RemoteSecurityGroupId == nil
+	bAny := b.RemoteCidr == nil && b.RemoteSecurityGroupId == nil
+	if aAny || bAny {
+		return true
+	}
+
+	if a.RemoteSecurityGroupId != nil || b.RemoteSecurityGroupId != nil {
+		// Members of a security group cannot be compared with a CIDR
+		return formatString(a.RemoteSecurityGroupId) == formatString(b.RemoteSecurityGroupId)
+	}
+
+	aPrefix, aErr := netip.ParsePrefix(*a.RemoteCidr)
+	bPrefix, bErr := netip.ParsePrefix(*b.RemoteCidr)
+	if aErr != nil || bErr != nil {
+		return *a.RemoteCidr == *b.RemoteCidr
+	}
+
+	return aPrefix.Overlaps(bPrefix)
+}
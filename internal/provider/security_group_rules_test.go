@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/genesiscloud/genesiscloud-go"
+)
+
+func testSecurityGroupRule(direction, protocol string, portRangeMin, portRangeMax *int, remoteCidr, remoteSecurityGroupId *string) genesiscloud.SecurityGroupRule {
+	return genesiscloud.SecurityGroupRule{
+		Direction:             genesiscloud.SecurityGroupRuleDirection(direction),
+		Protocol:              genesiscloud.SecurityGroupRuleProtocol(protocol),
+		PortRangeMin:          portRangeMin,
+		PortRangeMax:          portRangeMax,
+		RemoteCidr:            remoteCidr,
+		RemoteSecurityGroupId: remoteSecurityGroupId,
+	}
+}
+
+func TestNormalizeSecurityGroupRule(t *testing.T) {
+	testCases := map[string]struct {
+		rule     genesiscloud.SecurityGroupRule
+		expected genesiscloud.SecurityGroupRule
+	}{
+		"canonical": {
+			rule:     testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			expected: testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+		},
+		"uppercase": {
+			rule:     testSecurityGroupRule("INGRESS", "TCP", pointer(80), pointer(443), nil, nil),
+			expected: testSecurityGroupRule("ingress", "tcp", pointer(80), pointer(443), nil, nil),
+		},
+		"full-port-range": {
+			rule:     testSecurityGroupRule("ingress", "udp", pointer(1), pointer(65535), nil, nil),
+			expected: testSecurityGroupRule("ingress", "udp", nil, nil, nil, nil),
+		},
+		"only-min-port": {
+			rule:     testSecurityGroupRule("ingress", "tcp", pointer(8080), nil, nil, nil),
+			expected: testSecurityGroupRule("ingress", "tcp", pointer(8080), pointer(8080), nil, nil),
+		},
+		"only-max-port": {
+			rule:     testSecurityGroupRule("ingress", "tcp", nil, pointer(8080), nil, nil),
+			expected: testSecurityGroupRule("ingress", "tcp", pointer(8080), pointer(8080), nil, nil),
+		},
+		"icmp-implied-ports": {
+			rule:     testSecurityGroupRule("ingress", "icmp", pointer(1), pointer(65535), nil, nil),
+			expected: testSecurityGroupRule("ingress", "icmp", nil, nil, nil, nil),
+		},
+		"all-implied-ports": {
+			rule:     testSecurityGroupRule("egress", "all", pointer(1), pointer(65535), nil, nil),
+			expected: testSecurityGroupRule("egress", "all", nil, nil, nil, nil),
+		},
+		"remote-cidr-host-bits": {
+			rule:     testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.1/8"), nil),
+			expected: testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+		},
+		"remote-cidr-ipv6": {
+			rule:     testSecurityGroupRule("ingress", "tcp", nil, nil, pointer("2001:db8::1/32"), nil),
+			expected: testSecurityGroupRule("ingress", "tcp", nil, nil, pointer("2001:db8::/32"), nil),
+		},
+		"remote-security-group": {
+			rule:     testSecurityGroupRule("ingress", "ALL", nil, nil, nil, pointer("sg-1")),
+			expected: testSecurityGroupRule("ingress", "all", nil, nil, nil, pointer("sg-1")),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			actual := normalizeSecurityGroupRule(testCase.rule)
+
+			if securityGroupRuleKey(actual) != securityGroupRuleKey(testCase.expected) {
+				t.Errorf("expected %q, got %q", securityGroupRuleKey(testCase.expected), securityGroupRuleKey(actual))
+			}
+		})
+	}
+}
+
+func TestNormalizeSecurityGroupRules(t *testing.T) {
+	rules := []genesiscloud.SecurityGroupRule{
+		testSecurityGroupRule("egress", "all", nil, nil, nil, nil),
+		testSecurityGroupRule("ingress", "TCP", pointer(22), pointer(22), nil, nil),
+		testSecurityGroupRule("ingress", "tcp", pointer(22), nil, nil, nil),
+		testSecurityGroupRule("egress", "all", pointer(1), pointer(65535), nil, nil),
+	}
+
+	expected := []genesiscloud.SecurityGroupRule{
+		testSecurityGroupRule("egress", "all", nil, nil, nil, nil),
+		testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+	}
+
+	actual := normalizeSecurityGroupRules(rules)
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d rules, got %d", len(expected), len(actual))
+	}
+
+	for i := range expected {
+		if securityGroupRuleKey(actual[i]) != securityGroupRuleKey(expected[i]) {
+			t.Errorf("rule %d: expected %q, got %q", i, securityGroupRuleKey(expected[i]), securityGroupRuleKey(actual[i]))
+		}
+	}
+}
+
+func TestSecurityGroupRulesEqual(t *testing.T) {
+	testCases := map[string]struct {
+		a, b     []genesiscloud.SecurityGroupRule
+		expected bool
+	}{
+		"empty": {
+			expected: true,
+		},
+		"reordered": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+				testSecurityGroupRule("ingress", "tcp", pointer(443), pointer(443), nil, nil),
+			},
+			b: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(443), pointer(443), nil, nil),
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			},
+			expected: true,
+		},
+		"implied-ports": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "icmp", nil, nil, nil, nil),
+			},
+			b: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "icmp", pointer(1), pointer(65535), nil, nil),
+			},
+			expected: true,
+		},
+		"duplicates": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+				testSecurityGroupRule("ingress", "TCP", pointer(22), pointer(22), nil, nil),
+			},
+			b: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			},
+			expected: true,
+		},
+		"different-port": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			},
+			b: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(2222), pointer(2222), nil, nil),
+			},
+			expected: false,
+		},
+		"different-remote": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+			},
+			b: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			},
+			expected: false,
+		},
+		"missing-rule": {
+			a: []genesiscloud.SecurityGroupRule{
+				testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			},
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := securityGroupRulesEqual(testCase.a, testCase.b); actual != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupRulesOverlap(t *testing.T) {
+	testCases := map[string]struct {
+		a, b     genesiscloud.SecurityGroupRule
+		expected bool
+	}{
+		"different-direction": {
+			a:        testSecurityGroupRule("ingress", "tcp", nil, nil, nil, nil),
+			b:        testSecurityGroupRule("egress", "tcp", nil, nil, nil, nil),
+			expected: false,
+		},
+		"different-protocol": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(53), pointer(53), nil, nil),
+			b:        testSecurityGroupRule("ingress", "udp", pointer(53), pointer(53), nil, nil),
+			expected: false,
+		},
+		"all-protocols": {
+			a:        testSecurityGroupRule("ingress", "all", nil, nil, nil, nil),
+			b:        testSecurityGroupRule("ingress", "udp", pointer(53), pointer(53), nil, nil),
+			expected: true,
+		},
+		"intersecting-port-ranges": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(8000), pointer(8100), nil, nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(8080), pointer(8080), nil, nil),
+			expected: true,
+		},
+		"adjacent-port-ranges": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(8000), pointer(8079), nil, nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(8080), pointer(8080), nil, nil),
+			expected: false,
+		},
+		"any-remote": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+			expected: true,
+		},
+		"nested-cidrs": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.1.0.0/16"), nil),
+			expected: true,
+		},
+		"disjoint-cidrs": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("192.168.0.0/16"), nil),
+			expected: false,
+		},
+		"same-remote-security-group": {
+			a:        testSecurityGroupRule("ingress", "all", nil, nil, nil, pointer("sg-1")),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, pointer("sg-1")),
+			expected: true,
+		},
+		"cidr-and-security-group": {
+			a:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), pointer("10.0.0.0/8"), nil),
+			b:        testSecurityGroupRule("ingress", "tcp", pointer(22), pointer(22), nil, pointer("sg-1")),
+			expected: false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			a, b := normalizeSecurityGroupRule(testCase.a), normalizeSecurityGroupRule(testCase.b)
+
+			if actual := securityGroupRulesOverlap(a, b); actual != testCase.expected {
+				t.Errorf("expected %t, got %t", testCase.expected, actual)
+			}
+
+			if actual := securityGroupRulesOverlap(b, a); actual != testCase.expected {
+				t.Errorf("expected %t with swapped rules, got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
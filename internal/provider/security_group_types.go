@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/genesiscloud/genesiscloud-go"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -22,6 +25,126 @@ type SecurityGroupRuleModel struct {
 
 	// Protocol The protocol of the rule.
 	Protocol types.String `tfsdk:"protocol"`
+
+	// RemoteCidr The IPv4 or IPv6 network the rule applies to.
+	RemoteCidr types.String `tfsdk:"remote_cidr"`
+
+	// RemoteSecurityGroupId The id of the security group whose members the rule applies to.
+	RemoteSecurityGroupId types.String `tfsdk:"remote_security_group_id"`
+}
+
+// securityGroupRuleObjectType is the object type of a single element of the `rules` attribute.
+var securityGroupRuleObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"direction":                types.StringType,
+		"port_range_max":           types.Int64Type,
+		"port_range_min":           types.Int64Type,
+		"protocol":                 types.StringType,
+		"remote_cidr":              types.StringType,
+		"remote_security_group_id": types.StringType,
+	},
+}
+
+func (rule *SecurityGroupRuleModel) ToClientRequest() genesiscloud.SecurityGroupRule {
+	var portRangeMax, portRangeMin *int
+
+	if !rule.PortRangeMax.IsNull() && !rule.PortRangeMax.IsUnknown() {
+		portRangeMax = pointer(int(rule.PortRangeMax.ValueInt64()))
+	}
+
+	if !rule.PortRangeMin.IsNull() && !rule.PortRangeMin.IsUnknown() {
+		portRangeMin = pointer(int(rule.PortRangeMin.ValueInt64()))
+	}
+
+	var remoteCidr, remoteSecurityGroupId *string
+
+	if !rule.RemoteCidr.IsNull() && !rule.RemoteCidr.IsUnknown() {
+		remoteCidr = pointer(rule.RemoteCidr.ValueString())
+	}
+
+	if !rule.RemoteSecurityGroupId.IsNull() && !rule.RemoteSecurityGroupId.IsUnknown() {
+		remoteSecurityGroupId = pointer(rule.RemoteSecurityGroupId.ValueString())
+	}
+
+	return genesiscloud.SecurityGroupRule{
+		Direction:             genesiscloud.SecurityGroupRuleDirection(rule.Direction.ValueString()),
+		PortRangeMax:          portRangeMax,
+		PortRangeMin:          portRangeMin,
+		Protocol:              genesiscloud.SecurityGroupRuleProtocol(rule.Protocol.ValueString()),
+		RemoteCidr:            remoteCidr,
+		RemoteSecurityGroupId: remoteSecurityGroupId,
+	}
+}
+
+func (rule *SecurityGroupRuleModel) PopulateFromClientResponse(ctx context.Context, securityGroupRule *genesiscloud.SecurityGroupRule) (diag diag.Diagnostics) {
+	rule.Direction = types.StringValue(string(securityGroupRule.Direction))
+	rule.PortRangeMax = types.Int64Null()
+	rule.PortRangeMin = types.Int64Null()
+	rule.Protocol = types.StringValue(string(securityGroupRule.Protocol))
+	rule.RemoteCidr = types.StringNull()
+	rule.RemoteSecurityGroupId = types.StringNull()
+
+	if securityGroupRule.PortRangeMax != nil {
+		rule.PortRangeMax = types.Int64Value(int64(*securityGroupRule.PortRangeMax))
+	}
+
+	if securityGroupRule.PortRangeMin != nil {
+		rule.PortRangeMin = types.Int64Value(int64(*securityGroupRule.PortRangeMin))
+	}
+
+	if securityGroupRule.RemoteCidr != nil {
+		rule.RemoteCidr = types.StringValue(*securityGroupRule.RemoteCidr)
+	}
+
+	if securityGroupRule.RemoteSecurityGroupId != nil {
+		rule.RemoteSecurityGroupId = types.StringValue(*securityGroupRule.RemoteSecurityGroupId)
+	}
+
+	return
+}
+
+// Validate checks the rule for combinations of attributes that cannot be expressed by a single attribute validator.
+func (rule *SecurityGroupRuleModel) Validate(rulePath path.Path) (diag diag.Diagnostics) {
+	if !rule.RemoteCidr.IsNull() && !rule.RemoteSecurityGroupId.IsNull() {
+		diag.AddAttributeError(
+			rulePath.AtName("remote_security_group_id"),
+			"Invalid Attribute Combination",
+			"Only one of `remote_cidr` or `remote_security_group_id` can be specified for a rule.",
+		)
+	}
+
+	hasPorts := !rule.PortRangeMin.IsNull() || !rule.PortRangeMax.IsNull()
+
+	if hasPorts && !rule.Protocol.IsUnknown() && !securityGroupRuleHasPorts(genesiscloud.SecurityGroupRuleProtocol(rule.Protocol.ValueString())) {
+		diag.AddAttributeError(
+			rulePath.AtName("protocol"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("A port range can only be specified for the protocols \"tcp\" and \"udp\", got %q.", rule.Protocol.ValueString()),
+		)
+	}
+
+	if !rule.PortRangeMin.IsNull() && !rule.PortRangeMin.IsUnknown() &&
+		!rule.PortRangeMax.IsNull() && !rule.PortRangeMax.IsUnknown() &&
+		rule.PortRangeMin.ValueInt64() > rule.PortRangeMax.ValueInt64() {
+
+		diag.AddAttributeError(
+			rulePath.AtName("port_range_min"),
+			"Invalid Port Range",
+			fmt.Sprintf("The minimum port %d is greater than the maximum port %d.", rule.PortRangeMin.ValueInt64(), rule.PortRangeMax.ValueInt64()),
+		)
+	}
+
+	return
+}
+
+// IsKnown reports whether all rule attributes are known.
+func (rule *SecurityGroupRuleModel) IsKnown() bool {
+	return !rule.Direction.IsUnknown() &&
+		!rule.PortRangeMax.IsUnknown() &&
+		!rule.PortRangeMin.IsUnknown() &&
+		!rule.Protocol.IsUnknown() &&
+		!rule.RemoteCidr.IsUnknown() &&
+		!rule.RemoteSecurityGroupId.IsUnknown()
 }
 
 type SecurityGroupResourceModel struct {
@@ -40,7 +163,7 @@ type SecurityGroupResourceModel struct {
 	Region types.String `tfsdk:"region"`
 
 	// Rules The security group rules.
-	Rules []SecurityGroupRuleModel `tfsdk:"rules"`
+	Rules types.Set `tfsdk:"rules"`
 
 	// Status The security group status.
 	Status types.String `tfsdk:"status"`
@@ -58,28 +181,70 @@ func (data *SecurityGroupResourceModel) PopulateFromClientResponse(ctx context.C
 	data.Name = types.StringValue(securityGroup.Name)
 	data.Region = types.StringValue(string(securityGroup.Region))
 
-	data.Rules = nil
-	for _, rule := range securityGroup.Rules {
-		portRangeMax := types.Int64Null()
-		portRangeMin := types.Int64Null()
+	data.Rules, diag = securityGroupRulesFromClientResponse(ctx, data.Rules, securityGroup.Rules)
+	if diag.HasError() {
+		return
+	}
 
-		if rule.PortRangeMax != nil {
-			portRangeMax = types.Int64Value(int64(*rule.PortRangeMax))
-		}
+	data.Status = types.StringValue(string(securityGroup.Status))
 
-		if rule.PortRangeMin != nil {
-			portRangeMin = types.Int64Value(int64(*rule.PortRangeMin))
-		}
+	return
+}
+
+// RulesToClientRequest returns the normalized rules, see normalizeSecurityGroupRules.
+func (data *SecurityGroupResourceModel) RulesToClientRequest(ctx context.Context) ([]genesiscloud.SecurityGroupRule, diag.Diagnostics) {
+	return securityGroupRulesToClientRequest(ctx, data.Rules)
+}
+
+// securityGroupRulesToClientRequest returns the normalized rules of a `rules` set, see normalizeSecurityGroupRules.
+func securityGroupRulesToClientRequest(ctx context.Context, set types.Set) (rules []genesiscloud.SecurityGroupRule, diag diag.Diagnostics) {
+	rules = make([]genesiscloud.SecurityGroupRule, 0)
 
-		data.Rules = append(data.Rules, SecurityGroupRuleModel{
-			Direction:    types.StringValue(string(rule.Direction)),
-			PortRangeMax: portRangeMax,
-			PortRangeMin: portRangeMin,
-			Protocol:     types.StringValue(string(rule.Protocol)),
-		})
+	if set.IsNull() || set.IsUnknown() {
+		return
 	}
 
-	data.Status = types.StringValue(string(securityGroup.Status))
+	var ruleModels []SecurityGroupRuleModel
+	diag = set.ElementsAs(ctx, &ruleModels, false)
+	if diag.HasError() {
+		return
+	}
+
+	for _, rule := range ruleModels {
+		rules = append(rules, rule.ToClientRequest())
+	}
+
+	rules = normalizeSecurityGroupRules(rules)
 
 	return
 }
+
+// securityGroupRulesFromClientResponse returns the `rules` set for the remote rules.
+// The prior set is kept as it is known to Terraform if it is semantically
+// equal to the remote rules, e.g. if only the order or the notation differs.
+func securityGroupRulesFromClientResponse(ctx context.Context, prior types.Set, securityGroupRules []genesiscloud.SecurityGroupRule) (set types.Set, diag diag.Diagnostics) {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		current, diag := securityGroupRulesToClientRequest(ctx, prior)
+		if diag.HasError() {
+			return prior, diag
+		}
+
+		if securityGroupRulesEqual(current, securityGroupRules) {
+			return prior, diag
+		}
+	}
+
+	rules := make([]SecurityGroupRuleModel, 0, len(securityGroupRules))
+	for _, securityGroupRule := range normalizeSecurityGroupRules(securityGroupRules) {
+		rule := SecurityGroupRuleModel{}
+
+		diag.Append(rule.PopulateFromClientResponse(ctx, &securityGroupRule)...)
+		if diag.HasError() {
+			return
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return types.SetValueFrom(ctx, securityGroupRuleObjectType, rules)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ planmodifier.Int64 = sizePlanModifier{}
+
+// sizePlanModifier allows to increase the size of a volume or filesystem, which
+// is resized online. A decrease is an error, or a replacement with a warning
+// if the `replace_on_shrink` attribute of the resource is set.
+type sizePlanModifier struct {
+	// resourceName is the name of the resource in messages, e.g. `volume`.
+	resourceName string
+}
+
+func (m sizePlanModifier) Description(ctx context.Context) string {
+	return fmt.Sprintf("The size can only be increased, which resizes the %[1]s online. "+
+		"Decreasing the size is an error unless `replace_on_shrink` is set, which replaces the %[1]s instead.", m.resourceName)
+}
+
+func (m sizePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sizePlanModifier) PlanModifyInt64(ctx context.Context, req planmodifier.Int64Request, resp *planmodifier.Int64Response) {
+	// Nothing to resize on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+
+	current := req.StateValue.ValueInt64()
+	planned := req.PlanValue.ValueInt64()
+
+	if planned > current {
+		tflog.Info(ctx, fmt.Sprintf("the %s is resized online", m.resourceName), map[string]interface{}{"from": current, "to": planned})
+		return
+	}
+
+	if planned == current {
+		return
+	}
+
+	var replaceOnShrink types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("replace_on_shrink"), &replaceOnShrink)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !replaceOnShrink.ValueBool() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Cannot Decrease Size",
+			fmt.Sprintf("The size of the %[1]s cannot be decreased from %[2]d GiB to %[3]d GiB. "+
+				"Set `replace_on_shrink` to replace the %[1]s instead, which deletes all of its data.", m.resourceName, current, planned))
+		return
+	}
+
+	resp.RequiresReplace = true
+	resp.Diagnostics.AddAttributeWarning(req.Path, "Replacement to Decrease Size",
+		fmt.Sprintf("The %[1]s is replaced to decrease its size from %[2]d GiB to %[3]d GiB. All data of the %[1]s is lost.", m.resourceName, current, planned))
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSizePlanModifier(t *testing.T) {
+	testSchema := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"replace_on_shrink": schema.BoolAttribute{Optional: true},
+			"size":              schema.Int64Attribute{Required: true},
+		},
+	}
+
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"replace_on_shrink": tftypes.Bool,
+		"size":              tftypes.Number,
+	}}
+
+	raw := func(replaceOnShrink bool, size int64) tftypes.Value {
+		return tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"replace_on_shrink": tftypes.NewValue(tftypes.Bool, replaceOnShrink),
+			"size":              tftypes.NewValue(tftypes.Number, size),
+		})
+	}
+
+	testCases := map[string]struct {
+		create          bool
+		replaceOnShrink bool
+		planValue       types.Int64
+		requiresReplace bool
+		severity        diag.Severity
+	}{
+		"create":                     {create: true, planValue: types.Int64Value(10)},
+		"unchanged":                  {planValue: types.Int64Value(50)},
+		"unknown":                    {planValue: types.Int64Unknown()},
+		"increase":                   {planValue: types.Int64Value(100)},
+		"decrease":                   {planValue: types.Int64Value(10), severity: diag.SeverityError},
+		"decrease replace on shrink": {replaceOnShrink: true, planValue: types.Int64Value(10), requiresReplace: true, severity: diag.SeverityWarning},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := tfsdk.State{Schema: testSchema, Raw: raw(testCase.replaceOnShrink, 50)}
+			stateValue := types.Int64Value(50)
+			if testCase.create {
+				state.Raw = tftypes.NewValue(objectType, nil)
+				stateValue = types.Int64Null()
+			}
+
+			req := planmodifier.Int64Request{
+				Path:       path.Root("size"),
+				State:      state,
+				Plan:       tfsdk.Plan{Schema: testSchema, Raw: raw(testCase.replaceOnShrink, testCase.planValue.ValueInt64())},
+				StateValue: stateValue,
+				PlanValue:  testCase.planValue,
+			}
+			resp := &planmodifier.Int64Response{PlanValue: testCase.planValue}
+
+			sizePlanModifier{resourceName: "volume"}.PlanModifyInt64(context.Background(), req, resp)
+
+			if resp.RequiresReplace != testCase.requiresReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.requiresReplace, resp.RequiresReplace)
+			}
+
+			if testCase.severity == diag.SeverityInvalid {
+				if len(resp.Diagnostics) != 0 {
+					t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity() != testCase.severity {
+				t.Errorf("expected one diagnostic with severity %s, got %v", testCase.severity, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/datasourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource              = &SnapshotDataSource{}
+	_ datasource.DataSourceWithConfigure = &SnapshotDataSource{}
+)
+
+func NewSnapshotDataSource() datasource.DataSource {
+	return &SnapshotDataSource{}
+}
+
+// SnapshotDataSource defines the data source implementation.
+type SnapshotDataSource struct {
+	DataSourceWithClient
+	DataSourceWithTimeout
+}
+
+func (d *SnapshotDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot"
+}
+
+func (d *SnapshotDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := snapshotAttributes(ctx)
+
+	attributes["filter"] = snapshotsFilterAttribute(ctx)
+	attributes["most_recent"] = datasourceenhancer.Attribute(ctx, schema.BoolAttribute{
+		MarkdownDescription: "Flag to return the newest snapshot if several snapshots match. Otherwise several matching snapshots are an error.",
+		Optional:            true,
+	})
+
+	// Internal
+	attributes["timeouts"] = timeouts.Attributes(ctx)
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Snapshot data source. Looks up exactly one snapshot matching the `filter`, e.g. to use the newest snapshot as the image of an instance.",
+
+		Attributes: attributes,
+	}
+}
+
+func (d *SnapshotDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SnapshotDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	match, diag := data.Filter.Matcher()
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots, diag := listSnapshots(ctx, d.client)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots = filterSnapshots(snapshots, match)
+
+	if len(snapshots) == 0 {
+		resp.Diagnostics.AddError("No Matching Snapshot", "There is no snapshot matching the filter.")
+		return
+	}
+
+	if len(snapshots) > 1 && !data.MostRecent.ValueBool() {
+		ids := make([]string, 0, len(snapshots))
+		for _, snapshot := range snapshots {
+			ids = append(ids, fmt.Sprintf("  - %s: %s", snapshot.Id, snapshot.Name))
+		}
+
+		resp.Diagnostics.AddError("Multiple Matching Snapshots",
+			fmt.Sprintf("There are %d snapshots matching the filter. Set most_recent = true or narrow down the filter:\n%s", len(snapshots), strings.Join(ids, "\n")))
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &snapshots[0])...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
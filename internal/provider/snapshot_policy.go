@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// snapshotPolicyTimeFormat is appended to the name prefix of a policy snapshot,
+// so the names are unique and sort by creation.
+const snapshotPolicyTimeFormat = "20060102-150405"
+
+// snapshotPolicyName returns the name of a policy snapshot created at the given time.
+func snapshotPolicyName(prefix string, now time.Time) string {
+	return prefix + now.UTC().Format(snapshotPolicyTimeFormat)
+}
+
+// filterPolicySnapshots returns the snapshots of the source instance whose
+// name starts with the prefix, newest first.
+func filterPolicySnapshots(snapshots []genesiscloud.Snapshot, prefix string, sourceInstanceId string) []genesiscloud.Snapshot {
+	var result []genesiscloud.Snapshot
+
+	for _, snapshot := range snapshots {
+		if !strings.HasPrefix(snapshot.Name, prefix) {
+			continue
+		}
+
+		if snapshot.SourceInstanceId == nil || *snapshot.SourceInstanceId != sourceInstanceId {
+			continue
+		}
+
+		result = append(result, snapshot)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result
+}
+
+// snapshotPolicyDue reports whether a new snapshot has to be created because
+// the newest snapshot is older than the interval. Failed snapshots are ignored,
+// snapshots which are still being created count as recent.
+func snapshotPolicyDue(snapshots []genesiscloud.Snapshot, interval time.Duration, now time.Time) bool {
+	for _, snapshot := range snapshots {
+		if snapshot.Status == genesiscloud.SnapshotStatusError {
+			continue
+		}
+
+		return !snapshot.CreatedAt.Add(interval).After(now)
+	}
+
+	return true
+}
+
+// snapshotPolicyRetention decides which policy snapshots are kept. A zero
+// value of a rule disables it. Without any rule all snapshots are kept.
+type snapshotPolicyRetention struct {
+	// KeepLast keeps the given number of newest snapshots.
+	KeepLast int
+
+	// KeepWithin keeps the snapshots created within the duration.
+	KeepWithin time.Duration
+}
+
+// Expired returns the snapshots which are not kept by any rule. The snapshots
+// have to be sorted newest first. Snapshots which are still being created are
+// never expired, failed snapshots always are.
+func (r snapshotPolicyRetention) Expired(snapshots []genesiscloud.Snapshot, now time.Time) []genesiscloud.Snapshot {
+	var expired []genesiscloud.Snapshot
+
+	kept := 0
+
+	for _, snapshot := range snapshots {
+		switch snapshot.Status {
+		case genesiscloud.SnapshotStatusError:
+			expired = append(expired, snapshot)
+			continue
+		case genesiscloud.SnapshotStatusCreated:
+		default:
+			continue
+		}
+
+		keep := r.KeepLast == 0 && r.KeepWithin == 0
+
+		if r.KeepLast > 0 && kept < r.KeepLast {
+			keep = true
+		}
+
+		if r.KeepWithin > 0 && snapshot.CreatedAt.Add(r.KeepWithin).After(now) {
+			keep = true
+		}
+
+		if !keep {
+			expired = append(expired, snapshot)
+			continue
+		}
+
+		kept++
+	}
+
+	return expired
+}
+
+// listSnapshots returns all snapshots of the account.
+func listSnapshots(ctx context.Context, client *Client) ([]genesiscloud.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var snapshots []genesiscloud.Snapshot
+
+	for page := 1; ; page++ {
+		response, err := client.ListSnapshotsWithResponse(ctx, &genesiscloud.ListSnapshotsParams{
+			Page:    pointer(page),
+			PerPage: pointer(100),
+		})
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("read snapshots", err))
+			return nil, diags
+		}
+
+		snapshotsResponse := response.JSON200
+		if snapshotsResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("read snapshots", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return nil, diags
+		}
+
+		snapshots = append(snapshots, snapshotsResponse.Snapshots...)
+
+		if len(snapshotsResponse.Snapshots) < 100 {
+			// pagination done
+			break
+		}
+	}
+
+	return snapshots, diags
+}
+
+// waitForSnapshot polls the snapshot until it is created or failed.
+func waitForSnapshot(ctx context.Context, client *Client, snapshotId string) (*genesiscloud.Snapshot, diag.Diagnostics) {
+	for {
+		err := client.PollingWait(ctx)
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
+			return nil, diags
+		}
+
+		tflog.Trace(ctx, "polling a snapshot resource")
+
+		snapshot, diags := getSnapshot(ctx, client, snapshotId, "polling snapshot")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if snapshot.Status == genesiscloud.SnapshotStatusCreated || snapshot.Status == genesiscloud.SnapshotStatusError {
+			return snapshot, diags
+		}
+	}
+}
+
+// deleteSnapshot deletes the snapshot and waits until it is gone. An already
+// deleted snapshot is not an error.
+func deleteSnapshot(ctx context.Context, client *Client, snapshotId string) (diags diag.Diagnostics) {
+	response, err := client.DeleteSnapshotWithResponse(ctx, snapshotId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("delete snapshot", err))
+		return
+	}
+
+	if response.StatusCode() == 404 {
+		return
+	}
+
+	if response.StatusCode() != 204 {
+		diags.AddError("Client Error", generateClientErrorMessage("delete snapshot", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return
+	}
+
+	for {
+		err := client.PollingWait(ctx)
+		if err != nil {
+			diags.AddError("Polling Error", generateErrorMessage("polling snapshot", err))
+			return
+		}
+
+		tflog.Trace(ctx, "polling a snapshot resource")
+
+		response, err := client.GetSnapshotWithResponse(ctx, snapshotId)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("polling snapshot", err))
+			return
+		}
+
+		if response.StatusCode() == 404 {
+			return
+		}
+	}
+}
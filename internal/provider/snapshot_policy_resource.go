@@ -0,0 +1,400 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource               = &SnapshotPolicyResource{}
+	_ resource.ResourceWithConfigure  = &SnapshotPolicyResource{}
+	_ resource.ResourceWithModifyPlan = &SnapshotPolicyResource{}
+)
+
+func NewSnapshotPolicyResource() resource.Resource {
+	return &SnapshotPolicyResource{}
+}
+
+// SnapshotPolicyResource defines the resource implementation.
+type SnapshotPolicyResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *SnapshotPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshot_policy"
+}
+
+func (r *SnapshotPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Snapshot policy resource. Each apply creates a snapshot of the source instance if the newest snapshot is older than the `interval` " +
+			"and deletes the snapshots which are neither kept by `keep_last` nor by `keep_within`. " +
+			"The snapshots are identified by the `name_prefix` and the source instance, other snapshots are left alone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The name prefix of the snapshots.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"interval": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The minimum time between two snapshots, e.g. `24h`. A snapshot is only created by an apply, so the actual interval depends on how often Terraform is run.",
+				Required:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			}),
+			"keep_last": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The number of newest snapshots to keep.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			}),
+			"keep_within": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Keep all snapshots created within this duration, e.g. `168h`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			}),
+			"name_prefix": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The prefix of the names of the snapshots, e.g. `nightly-`. The creation time is appended to the prefix.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			}),
+			"snapshots": schema.ListNestedAttribute{
+				MarkdownDescription: "The managed snapshots, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The timestamp when the snapshot was created in RFC 3339.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique ID of the snapshot.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the snapshot.",
+							Computed:            true,
+						},
+						"size": schema.Int64Attribute{
+							MarkdownDescription: "The storage size of the snapshot given in GiB.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The snapshot status.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"source_instance_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the instance to snapshot.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			}),
+
+			// Internal
+			"retain_on_delete": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to retain the snapshots when the resource is deleted. Otherwise all managed snapshots are deleted.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					defaultplanmodifier.Bool(false),
+				},
+			}),
+
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *SnapshotPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The snapshots are unknown on create anyway, nothing to rotate on destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state SnapshotPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || !plan.IsKnown() {
+		return
+	}
+
+	snapshots, diag := state.StateSnapshots(ctx)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	due := snapshotPolicyDue(snapshots, plan.IntervalValue(), now)
+	expired := plan.Retention().Expired(snapshots, now)
+
+	// Keep the snapshots unless the rotation changes them, so a policy without a due rotation has no diff
+	if due || len(expired) > 0 {
+		tflog.Debug(ctx, "snapshot_policy rotation planned", map[string]interface{}{"due": due, "expired": len(expired)})
+		plan.Snapshots = types.ListUnknown(types.ObjectType{AttrTypes: snapshotPolicySnapshotAttrTypes})
+	} else {
+		plan.Snapshots = state.Snapshots
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *SnapshotPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SnapshotPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	resp.Diagnostics.Append(r.rotate(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a snapshot_policy resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SnapshotPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	snapshots, diag := r.listPolicySnapshots(ctx, &data)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, snapshots)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a snapshot_policy resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SnapshotPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	// The snapshots are only unknown if the plan rotates them, the rotation is not reconsidered at apply time
+	if data.Snapshots.IsUnknown() {
+		resp.Diagnostics.Append(r.rotate(ctx, &data)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated a snapshot_policy resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SnapshotPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SnapshotPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	if data.RetainOnDelete.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Snapshots are retained",
+			fmt.Sprintf("The snapshot_policy resource with id %q was deleted from the state but its snapshots are retained.", data.Id.ValueString()),
+		)
+		return
+	}
+
+	snapshots, diag := r.listPolicySnapshots(ctx, &data)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, snapshot := range snapshots {
+		resp.Diagnostics.Append(deleteSnapshot(ctx, r.client, snapshot.Id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+// listPolicySnapshots returns the snapshots managed by the policy, newest first.
+func (r *SnapshotPolicyResource) listPolicySnapshots(ctx context.Context, data *SnapshotPolicyResourceModel) ([]genesiscloud.Snapshot, diag.Diagnostics) {
+	snapshots, diags := listSnapshots(ctx, r.client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	return filterPolicySnapshots(snapshots, data.NamePrefix.ValueString(), data.SourceInstanceId.ValueString()), diags
+}
+
+// rotate creates a snapshot if the newest one is older than the interval and
+// deletes the snapshots which are not kept by the retention rules.
+func (r *SnapshotPolicyResource) rotate(ctx context.Context, data *SnapshotPolicyResourceModel) (diags diag.Diagnostics) {
+	snapshots, diag := r.listPolicySnapshots(ctx, data)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return
+	}
+
+	now := time.Now()
+
+	if snapshotPolicyDue(snapshots, data.IntervalValue(), now) {
+		snapshot, diag := r.createSnapshot(ctx, data.SourceInstanceId.ValueString(), snapshotPolicyName(data.NamePrefix.ValueString(), now))
+		diags.Append(diag...)
+		if diags.HasError() {
+			return
+		}
+
+		snapshots = append([]genesiscloud.Snapshot{*snapshot}, snapshots...)
+	}
+
+	expired := data.Retention().Expired(snapshots, now)
+
+	tflog.Debug(ctx, "rotating snapshot_policy", map[string]interface{}{
+		"snapshots": len(snapshots),
+		"expired":   len(expired),
+	})
+
+	deleted := make(map[string]bool, len(expired))
+	for _, snapshot := range expired {
+		diags.Append(deleteSnapshot(ctx, r.client, snapshot.Id)...)
+		if diags.HasError() {
+			return
+		}
+
+		deleted[snapshot.Id] = true
+	}
+
+	var result []genesiscloud.Snapshot
+	for _, snapshot := range snapshots {
+		if !deleted[snapshot.Id] {
+			result = append(result, snapshot)
+		}
+	}
+
+	diags.Append(data.PopulateFromClientResponse(ctx, result)...)
+
+	return
+}
+
+// createSnapshot creates a snapshot of the instance and waits until it is created.
+func (r *SnapshotPolicyResource) createSnapshot(ctx context.Context, instanceId string, name string) (*genesiscloud.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	body := genesiscloud.CreateInstanceSnapshotJSONRequestBody{}
+	body.Name = name
+
+	response, err := r.client.CreateInstanceSnapshotWithResponse(ctx, instanceId, body)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("create snapshot", err))
+		return nil, diags
+	}
+
+	snapshotResponse := response.JSON201
+	if snapshotResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage("create snapshot", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	tflog.Info(ctx, "created a policy snapshot", map[string]interface{}{"id": snapshotResponse.Snapshot.Id, "name": name})
+
+	snapshot, diags := waitForSnapshot(ctx, r.client, snapshotResponse.Snapshot.Id)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if snapshot.Status == genesiscloud.SnapshotStatusError {
+		diags.AddError("Provisioning Error", generateErrorMessage("polling snapshot", ErrResourceInErrorState))
+		return nil, diags
+	}
+
+	return snapshot, diags
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSnapshotPolicyResourceConfig(namePrefix string, keepLast int) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_instance" "test" {
+  name = "acc-test-snapshot-policy"
+}
+
+resource "genesiscloud_snapshot_policy" "test" {
+  source_instance_id = genesiscloud_instance.test.id
+  name_prefix        = %[1]q
+  interval           = "24h"
+  keep_last          = %[2]d
+}
+`, namePrefix, keepLast)
+}
+
+func TestAccSnapshotPolicyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSnapshotPolicyResourceConfig("acc-test-", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_snapshot_policy.test", "id", "acc-test-"),
+					resource.TestCheckResourceAttr("genesiscloud_snapshot_policy.test", "snapshots.#", "1"),
+				),
+			},
+			// Update and Read testing, the snapshot is not due again
+			{
+				Config: providerConfig + testAccSnapshotPolicyResourceConfig("acc-test-", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_snapshot_policy.test", "snapshots.#", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
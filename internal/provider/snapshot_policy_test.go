@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+)
+
+var snapshotPolicyNow = time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+// testPolicySnapshot returns a policy snapshot created the given duration before snapshotPolicyNow.
+func testPolicySnapshot(id string, age time.Duration, status genesiscloud.SnapshotStatus) genesiscloud.Snapshot {
+	return genesiscloud.Snapshot{
+		CreatedAt:        snapshotPolicyNow.Add(-age),
+		Id:               id,
+		Name:             "nightly-" + id,
+		SourceInstanceId: pointer("instance"),
+		Status:           status,
+	}
+}
+
+func snapshotIds(snapshots []genesiscloud.Snapshot) string {
+	ids := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		ids = append(ids, snapshot.Id)
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestSnapshotPolicyName(t *testing.T) {
+	if name := snapshotPolicyName("nightly-", snapshotPolicyNow); name != "nightly-20240310-120000" {
+		t.Errorf("unexpected name %q", name)
+	}
+}
+
+func TestFilterPolicySnapshots(t *testing.T) {
+	otherInstance := testPolicySnapshot("other-instance", time.Hour, genesiscloud.SnapshotStatusCreated)
+	otherInstance.SourceInstanceId = pointer("other")
+
+	otherName := testPolicySnapshot("other-name", time.Hour, genesiscloud.SnapshotStatusCreated)
+	otherName.Name = "manual"
+
+	snapshots := []genesiscloud.Snapshot{
+		testPolicySnapshot("old", 48*time.Hour, genesiscloud.SnapshotStatusCreated),
+		otherInstance,
+		testPolicySnapshot("new", time.Hour, genesiscloud.SnapshotStatusCreated),
+		otherName,
+	}
+
+	if ids := snapshotIds(filterPolicySnapshots(snapshots, "nightly-", "instance")); ids != "new,old" {
+		t.Errorf("expected new,old, got %s", ids)
+	}
+}
+
+func TestSnapshotPolicyDue(t *testing.T) {
+	testCases := map[string]struct {
+		snapshots []genesiscloud.Snapshot
+		due       bool
+	}{
+		"none": {due: true},
+		"recent": {
+			snapshots: []genesiscloud.Snapshot{testPolicySnapshot("a", time.Hour, genesiscloud.SnapshotStatusCreated)},
+		},
+		"old": {
+			snapshots: []genesiscloud.Snapshot{testPolicySnapshot("a", 25*time.Hour, genesiscloud.SnapshotStatusCreated)},
+			due:       true,
+		},
+		"exactly the interval": {
+			snapshots: []genesiscloud.Snapshot{testPolicySnapshot("a", 24*time.Hour, genesiscloud.SnapshotStatusCreated)},
+			due:       true,
+		},
+		"recent failed": {
+			snapshots: []genesiscloud.Snapshot{
+				testPolicySnapshot("a", time.Hour, genesiscloud.SnapshotStatusError),
+				testPolicySnapshot("b", 25*time.Hour, genesiscloud.SnapshotStatusCreated),
+			},
+			due: true,
+		},
+		"recent pending": {
+			snapshots: []genesiscloud.Snapshot{testPolicySnapshot("a", time.Hour, "creating")},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if due := snapshotPolicyDue(testCase.snapshots, 24*time.Hour, snapshotPolicyNow); due != testCase.due {
+				t.Errorf("expected %t, got %t", testCase.due, due)
+			}
+		})
+	}
+}
+
+func TestSnapshotPolicyRetentionExpired(t *testing.T) {
+	snapshots := []genesiscloud.Snapshot{
+		testPolicySnapshot("pending", 0, "creating"),
+		testPolicySnapshot("day-1", 24*time.Hour, genesiscloud.SnapshotStatusCreated),
+		testPolicySnapshot("failed", 36*time.Hour, genesiscloud.SnapshotStatusError),
+		testPolicySnapshot("day-2", 48*time.Hour, genesiscloud.SnapshotStatusCreated),
+		testPolicySnapshot("day-3", 72*time.Hour, genesiscloud.SnapshotStatusCreated),
+		testPolicySnapshot("day-8", 192*time.Hour, genesiscloud.SnapshotStatusCreated),
+	}
+
+	testCases := map[string]struct {
+		retention snapshotPolicyRetention
+		expired   string
+	}{
+		"no rules":    {expired: "failed"},
+		"keep last":   {retention: snapshotPolicyRetention{KeepLast: 2}, expired: "failed,day-3,day-8"},
+		"keep within": {retention: snapshotPolicyRetention{KeepWithin: 60 * time.Hour}, expired: "failed,day-3,day-8"},
+		"both": {
+			retention: snapshotPolicyRetention{KeepLast: 1, KeepWithin: 80 * time.Hour},
+			expired:   "failed,day-8",
+		},
+		"keep last beyond within": {
+			retention: snapshotPolicyRetention{KeepLast: 4, KeepWithin: time.Hour},
+			expired:   "failed",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if expired := snapshotIds(testCase.retention.Expired(snapshots, snapshotPolicyNow)); expired != testCase.expired {
+				t.Errorf("expected %s, got %s", testCase.expired, expired)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SnapshotPolicyResourceModel struct {
+	// Id The name prefix of the snapshots.
+	Id types.String `tfsdk:"id"`
+
+	// Interval The minimum time between two snapshots.
+	Interval types.String `tfsdk:"interval"`
+
+	// KeepLast The number of newest snapshots to keep.
+	KeepLast types.Int64 `tfsdk:"keep_last"`
+
+	// KeepWithin The duration within which all snapshots are kept.
+	KeepWithin types.String `tfsdk:"keep_within"`
+
+	// NamePrefix The prefix of the names of the snapshots.
+	NamePrefix types.String `tfsdk:"name_prefix"`
+
+	// Snapshots The managed snapshots, newest first.
+	Snapshots types.List `tfsdk:"snapshots"`
+
+	// SourceInstanceId The id of the instance to snapshot.
+	SourceInstanceId types.String `tfsdk:"source_instance_id"`
+
+	// Internal
+
+	// RetainOnDelete Flag to retain the snapshots when the resource is deleted.
+	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+type SnapshotPolicySnapshotModel struct {
+	CreatedAt types.String `tfsdk:"created_at"`
+
+	// Id The unique ID of the snapshot.
+	Id types.String `tfsdk:"id"`
+
+	// Name The name of the snapshot.
+	Name types.String `tfsdk:"name"`
+
+	// Size The storage size of the snapshot given in GiB.
+	Size types.Int64 `tfsdk:"size"`
+
+	// Status The snapshot status.
+	Status types.String `tfsdk:"status"`
+}
+
+var snapshotPolicySnapshotAttrTypes = map[string]attr.Type{
+	"created_at": types.StringType,
+	"id":         types.StringType,
+	"name":       types.StringType,
+	"size":       types.Int64Type,
+	"status":     types.StringType,
+}
+
+// IntervalValue returns the minimum time between two snapshots.
+func (data *SnapshotPolicyResourceModel) IntervalValue() time.Duration {
+	interval, _ := time.ParseDuration(data.Interval.ValueString())
+	return interval
+}
+
+// Retention returns the configured retention rules.
+func (data *SnapshotPolicyResourceModel) Retention() snapshotPolicyRetention {
+	retention := snapshotPolicyRetention{
+		KeepLast: int(data.KeepLast.ValueInt64()),
+	}
+
+	if !data.KeepWithin.IsNull() {
+		retention.KeepWithin, _ = time.ParseDuration(data.KeepWithin.ValueString())
+	}
+
+	return retention
+}
+
+// IsKnown reports whether the schedule and retention rules are known.
+func (data *SnapshotPolicyResourceModel) IsKnown() bool {
+	return !data.Interval.IsUnknown() && !data.KeepLast.IsUnknown() && !data.KeepWithin.IsUnknown()
+}
+
+// StateSnapshots returns the snapshots in the state, newest first.
+func (data *SnapshotPolicyResourceModel) StateSnapshots(ctx context.Context) (snapshots []genesiscloud.Snapshot, diags diag.Diagnostics) {
+	if data.Snapshots.IsNull() || data.Snapshots.IsUnknown() {
+		return nil, diags
+	}
+
+	var models []SnapshotPolicySnapshotModel
+	diags.Append(data.Snapshots.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, model := range models {
+		createdAt, _ := time.Parse(time.RFC3339, model.CreatedAt.ValueString())
+
+		snapshots = append(snapshots, genesiscloud.Snapshot{
+			CreatedAt: createdAt,
+			Id:        model.Id.ValueString(),
+			Name:      model.Name.ValueString(),
+			Size:      int(model.Size.ValueInt64()),
+			Status:    genesiscloud.SnapshotStatus(model.Status.ValueString()),
+		})
+	}
+
+	return snapshots, diags
+}
+
+func (data *SnapshotPolicyResourceModel) PopulateFromClientResponse(ctx context.Context, snapshots []genesiscloud.Snapshot) (diags diag.Diagnostics) {
+	models := make([]SnapshotPolicySnapshotModel, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		models = append(models, SnapshotPolicySnapshotModel{
+			CreatedAt: types.StringValue(snapshot.CreatedAt.Format(time.RFC3339)),
+			Id:        types.StringValue(snapshot.Id),
+			Name:      types.StringValue(snapshot.Name),
+			Size:      types.Int64Value(int64(snapshot.Size)),
+			Status:    types.StringValue(string(snapshot.Status)),
+		})
+	}
+
+	data.Id = types.StringValue(data.NamePrefix.ValueString())
+
+	data.Snapshots, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: snapshotPolicySnapshotAttrTypes}, models)
+
+	return
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// filterSnapshotReplicas returns the replicas of the snapshot, i.e. the
+// snapshots derived from it in one of the regions, sorted by region.
+func filterSnapshotReplicas(snapshots []genesiscloud.Snapshot, snapshotId string, regions []string) []genesiscloud.Snapshot {
+	var replicas []genesiscloud.Snapshot
+
+	for _, snapshot := range snapshots {
+		if snapshot.SourceSnapshotId == nil || *snapshot.SourceSnapshotId != snapshotId {
+			continue
+		}
+
+		for _, region := range regions {
+			if string(snapshot.Region) == region {
+				replicas = append(replicas, snapshot)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(replicas, func(i, j int) bool {
+		return replicas[i].Region < replicas[j].Region
+	})
+
+	return replicas
+}
+
+// discoverSnapshotReplicas returns the replicas of the snapshot in the first
+// other region than its own region, which has any, together with that region.
+// The region is empty if the snapshot has no replicas. It is only used on
+// import, as copies of the snapshot managed elsewhere match as well.
+func discoverSnapshotReplicas(snapshots []genesiscloud.Snapshot, snapshotId string, snapshotRegion string) (string, []genesiscloud.Snapshot) {
+	var regions []string
+	for _, region := range genesiscloud.AllRegions {
+		if string(region) != snapshotRegion {
+			regions = append(regions, string(region))
+		}
+	}
+
+	replicas := filterSnapshotReplicas(snapshots, snapshotId, regions)
+	if len(replicas) == 0 {
+		return "", nil
+	}
+
+	region := string(replicas[0].Region)
+
+	return region, filterSnapshotReplicas(replicas, snapshotId, []string{region})
+}
+
+// findSnapshot returns the snapshot or nil if it does not exist.
+func findSnapshot(ctx context.Context, client *Client, snapshotId string) (*genesiscloud.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := client.GetSnapshotWithResponse(ctx, snapshotId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage("read snapshot replica", err))
+		return nil, diags
+	}
+
+	if response.StatusCode() == 404 {
+		return nil, diags
+	}
+
+	snapshotResponse := response.JSON200
+	if snapshotResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage("read snapshot replica", ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &snapshotResponse.Snapshot, diags
+}
+
+// waitForSnapshotReplicas waits until the replicas are created. A failed replica is an error.
+func waitForSnapshotReplicas(ctx context.Context, client *Client, replicas []genesiscloud.Snapshot) ([]genesiscloud.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	result := make([]genesiscloud.Snapshot, 0, len(replicas))
+
+	for _, replica := range replicas {
+		tflog.Info(ctx, "waiting for the replication of a snapshot", map[string]interface{}{"id": replica.Id, "region": string(replica.Region)})
+
+		snapshot, diag := waitForSnapshot(ctx, client, replica.Id)
+		diags.Append(diag...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if snapshot.Status == genesiscloud.SnapshotStatusError {
+			diags.AddError("Replication Error", generateErrorMessage("polling snapshot replica", ErrResourceInErrorState))
+			return nil, diags
+		}
+
+		result = append(result, *snapshot)
+	}
+
+	return result, diags
+}
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFilterSnapshotReplicas(t *testing.T) {
+	snapshots := []genesiscloud.Snapshot{
+		{Id: "source", Region: "NORD-NO-KRS-1"},
+		{Id: "replica-ams", Region: "EUW-NL-AMS-1", SourceSnapshotId: pointer("source")},
+		{Id: "replica-muc", Region: "EUC-DE-MUC-1", SourceSnapshotId: pointer("source")},
+		{Id: "other", Region: "EUC-DE-MUC-1", SourceSnapshotId: pointer("other-source")},
+		{Id: "instance", Region: "EUC-DE-MUC-1", SourceInstanceId: pointer("instance")},
+	}
+
+	testCases := map[string]struct {
+		regions []string
+		ids     string
+	}{
+		"no regions":    {},
+		"one region":    {regions: []string{"EUC-DE-MUC-1"}, ids: "replica-muc"},
+		"two regions":   {regions: []string{"EUW-NL-AMS-1", "EUC-DE-MUC-1"}, ids: "replica-muc,replica-ams"},
+		"other regions": {regions: []string{"NA-CA-FTS-1"}},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if ids := snapshotIds(filterSnapshotReplicas(snapshots, "source", testCase.regions)); ids != testCase.ids {
+				t.Errorf("expected %q, got %q", testCase.ids, ids)
+			}
+		})
+	}
+}
+
+func TestDiscoverSnapshotReplicas(t *testing.T) {
+	snapshots := []genesiscloud.Snapshot{
+		{Id: "source", Region: "NORD-NO-KRS-1"},
+		{Id: "clone", Region: "NORD-NO-KRS-1", SourceSnapshotId: pointer("source")},
+		{Id: "replica-ams", Region: "EUW-NL-AMS-1", SourceSnapshotId: pointer("source")},
+		{Id: "replica-muc", Region: "EUC-DE-MUC-1", SourceSnapshotId: pointer("source")},
+	}
+
+	region, replicas := discoverSnapshotReplicas(snapshots, "source", "NORD-NO-KRS-1")
+	if region != "EUC-DE-MUC-1" || snapshotIds(replicas) != "replica-muc" {
+		t.Errorf("unexpected replicas %q in %q", snapshotIds(replicas), region)
+	}
+
+	region, replicas = discoverSnapshotReplicas(snapshots[:2], "source", "NORD-NO-KRS-1")
+	if region != "" || len(replicas) != 0 {
+		t.Errorf("expected no replicas, got %q in %q", snapshotIds(replicas), region)
+	}
+}
+
+// fakeSnapshotAPI serves snapshots whose clones are created after one poll.
+// Clones are never listed, like new snapshots of the real API right after
+// their creation.
+type fakeSnapshotAPI struct {
+	mu        sync.Mutex
+	snapshots map[string]map[string]interface{}
+	clones    int
+	deleted   []string
+}
+
+func (f *fakeSnapshotAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	var snapshotId string
+	for _, segment := range segments {
+		if f.snapshots[segment] != nil {
+			snapshotId = segment
+		}
+	}
+
+	switch {
+	case r.Method == http.MethodPost && snapshotId != "":
+		var body struct {
+			Name   string `json:"name"`
+			Region string `json:"region"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		f.clones++
+		clone := map[string]interface{}{
+			"id":                 fmt.Sprintf("clone-%d", f.clones),
+			"name":               body.Name,
+			"region":             body.Region,
+			"status":             "pending",
+			"source_snapshot_id": snapshotId,
+		}
+		f.snapshots[clone["id"].(string)] = clone
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"snapshot": clone})
+	case r.Method == http.MethodGet && snapshotId != "":
+		snapshot := f.snapshots[snapshotId]
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"snapshot": snapshot})
+
+		snapshot["status"] = string(genesiscloud.SnapshotStatusCreated)
+	case r.Method == http.MethodDelete && snapshotId != "":
+		delete(f.snapshots, snapshotId)
+		f.deleted = append(f.deleted, snapshotId)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found","message":"not found"}`))
+	}
+}
+
+func newFakeSnapshotResource(t *testing.T, snapshots ...map[string]interface{}) (*SnapshotResource, *fakeSnapshotAPI) {
+	t.Helper()
+
+	api := &fakeSnapshotAPI{snapshots: map[string]map[string]interface{}{}}
+	for _, snapshot := range snapshots {
+		api.snapshots[snapshot["id"].(string)] = snapshot
+	}
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), ClientConfig{
+		ClientConfig: genesiscloud.ClientConfig{
+			Endpoint: server.URL,
+			Token:    "fake-token",
+		},
+		PollingInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	r := &SnapshotResource{}
+	r.client = client
+
+	return r, api
+}
+
+func TestSnapshotResourceReplicate(t *testing.T) {
+	source := map[string]interface{}{"id": "source", "region": "NORD-NO-KRS-1", "status": "created"}
+	replica := map[string]interface{}{"id": "replica-ams", "region": "EUW-NL-AMS-1", "status": "created", "source_snapshot_id": "source"}
+
+	testCases := map[string]struct {
+		current          []genesiscloud.Snapshot
+		replicatedRegion types.String
+		waitForReplicas  bool
+		replicas         string
+		status           genesiscloud.SnapshotStatus
+		deleted          string
+	}{
+		"create": {
+			replicatedRegion: types.StringValue("EUC-DE-MUC-1"),
+			replicas:         "clone-1",
+			status:           "pending",
+		},
+		"create and wait": {
+			replicatedRegion: types.StringValue("EUC-DE-MUC-1"),
+			waitForReplicas:  true,
+			replicas:         "clone-1",
+			status:           genesiscloud.SnapshotStatusCreated,
+		},
+		"change region": {
+			current:          []genesiscloud.Snapshot{{Id: "replica-ams", Region: "EUW-NL-AMS-1"}},
+			replicatedRegion: types.StringValue("EUC-DE-MUC-1"),
+			replicas:         "clone-1",
+			status:           "pending",
+			deleted:          "replica-ams",
+		},
+		"remove region": {
+			current:          []genesiscloud.Snapshot{{Id: "replica-ams", Region: "EUW-NL-AMS-1"}},
+			replicatedRegion: types.StringNull(),
+			deleted:          "replica-ams",
+		},
+		"unchanged region": {
+			current:          []genesiscloud.Snapshot{{Id: "replica-ams", Region: "EUW-NL-AMS-1", Status: genesiscloud.SnapshotStatusCreated}},
+			replicatedRegion: types.StringValue("EUW-NL-AMS-1"),
+			replicas:         "replica-ams",
+			status:           genesiscloud.SnapshotStatusCreated,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, api := newFakeSnapshotResource(t, source, replica)
+
+			data := SnapshotResourceModel{
+				SnapshotModel:    SnapshotModel{Id: types.StringValue("source"), Name: types.StringValue("source")},
+				ReplicatedRegion: testCase.replicatedRegion,
+				WaitForReplicas:  types.BoolValue(testCase.waitForReplicas),
+			}
+
+			diags := r.replicate(context.Background(), &data, testCase.current)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			replicas, diags := data.TrackedReplicas(context.Background())
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if ids := snapshotIds(replicas); ids != testCase.replicas {
+				t.Errorf("expected the replicas %q, got %q", testCase.replicas, ids)
+			}
+			if len(replicas) > 0 && replicas[0].Status != testCase.status {
+				t.Errorf("expected the status %q, got %q", testCase.status, replicas[0].Status)
+			}
+			if deleted := strings.Join(api.deleted, ","); deleted != testCase.deleted {
+				t.Errorf("expected the deleted snapshots %q, got %q", testCase.deleted, deleted)
+			}
+		})
+	}
+}
+
+func TestSnapshotResourceReadReplicas(t *testing.T) {
+	replica := map[string]interface{}{"id": "replica-ams", "region": "EUW-NL-AMS-1", "status": "created", "source_snapshot_id": "source"}
+	copied := map[string]interface{}{"id": "copy-muc", "region": "EUC-DE-MUC-1", "status": "created", "source_snapshot_id": "source"}
+
+	testCases := map[string]struct {
+		tracked          []genesiscloud.Snapshot
+		replicatedRegion types.String
+		expectedRegion   types.String
+		replicas         string
+	}{
+		"tracked": {
+			tracked:          []genesiscloud.Snapshot{{Id: "replica-ams", Region: "EUW-NL-AMS-1"}},
+			replicatedRegion: types.StringValue("EUW-NL-AMS-1"),
+			expectedRegion:   types.StringValue("EUW-NL-AMS-1"),
+			replicas:         "replica-ams",
+		},
+		"deleted": {
+			tracked:          []genesiscloud.Snapshot{{Id: "replica-gone", Region: "EUW-NL-AMS-1"}},
+			replicatedRegion: types.StringValue("EUW-NL-AMS-1"),
+			expectedRegion:   types.StringNull(),
+		},
+		// State written before the replicas were tracked ignores the copies of other resources
+		"untracked": {
+			replicatedRegion: types.StringValue("EUC-DE-MUC-1"),
+			expectedRegion:   types.StringValue("EUC-DE-MUC-1"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			r, api := newFakeSnapshotResource(t, replica, copied)
+
+			data := SnapshotResourceModel{
+				SnapshotModel:    SnapshotModel{Id: types.StringValue("source"), Region: types.StringValue("NORD-NO-KRS-1")},
+				ReplicatedRegion: testCase.replicatedRegion,
+				Replicas:         types.ListNull(types.ObjectType{AttrTypes: snapshotReplicaAttrTypes}),
+			}
+			if testCase.tracked != nil {
+				data.PopulateReplicas(context.Background(), testCase.tracked)
+			}
+
+			diags := r.readReplicas(context.Background(), &data, false)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			replicas, _ := data.TrackedReplicas(context.Background())
+			if ids := snapshotIds(replicas); ids != testCase.replicas {
+				t.Errorf("expected the replicas %q, got %q", testCase.replicas, ids)
+			}
+			if !data.ReplicatedRegion.Equal(testCase.expectedRegion) {
+				t.Errorf("expected the replicated region %s, got %s", testCase.expectedRegion, data.ReplicatedRegion)
+			}
+			if len(api.deleted) != 0 {
+				t.Errorf("expected no deleted snapshots, got %v", api.deleted)
+			}
+		})
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/defaultplanmodifier"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -22,6 +24,7 @@ var (
 	_ resource.Resource                = &SnapshotResource{}
 	_ resource.ResourceWithConfigure   = &SnapshotResource{}
 	_ resource.ResourceWithImportState = &SnapshotResource{}
+	_ resource.ResourceWithModifyPlan  = &SnapshotResource{}
 )
 
 func NewSnapshotResource() resource.Resource {
@@ -63,10 +66,32 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 				Required:            true,
 			}),
 			"replicated_region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "Target region for snapshot replication. When specified, also creates a copy of the snapshot in the given region. If omitted, the snapshot exists only in the current region.",
-				Required:            false,
-				Optional:            true,
+				MarkdownDescription: "Target region for snapshot replication. When specified, also creates a copy of the snapshot in the given region. If omitted, the snapshot exists only in the current region. " +
+					"Changing or removing the region deletes the replica in the previous region. Only the replicas created by this resource are tracked. " +
+					"The region is discovered from the existing copies in other regions on import. If the replica is deleted outside of Terraform, it is created again by the next apply.",
+				Required: false,
+				Optional: true,
 			}),
+			"replicas": schema.ListNestedAttribute{
+				MarkdownDescription: "The replicas of the snapshot in the `replicated_region`, e.g. to create instances in the other region.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique ID of the replica snapshot.",
+							Computed:            true,
+						},
+						"region": schema.StringAttribute{
+							MarkdownDescription: "The region identifier of the replica.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the replica snapshot.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 			"region": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The region identifier. Should only be explicity specified when using the 'source_snapshot_id'.",
 				Optional:            true,
@@ -113,12 +138,43 @@ func (r *SnapshotResource) Schema(ctx context.Context, req resource.SchemaReques
 					defaultplanmodifier.Bool(false),
 				},
 			}),
+			"wait_for_replicas": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to wait until the replicas are created. Otherwise the replication continues in the background and the status of the replicas is updated by the next refresh.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					defaultplanmodifier.Bool(false),
+				},
+			}),
 
 			"timeouts": timeouts.AttributesAll(ctx),
 		},
 	}
 }
 
+func (r *SnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The replicas are unknown on create anyway, nothing to replicate on destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state SnapshotResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The replicas only change with the replicated region
+	if plan.ReplicatedRegion.Equal(state.ReplicatedRegion) {
+		plan.Replicas = state.Replicas
+	} else {
+		plan.Replicas = types.ListUnknown(types.ObjectType{AttrTypes: snapshotReplicaAttrTypes})
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SnapshotResourceModel
 
@@ -168,10 +224,6 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 
 		instanceId := data.SourceInstanceId.ValueString()
 
-		if !data.ReplicatedRegion.IsNull() {
-			body.ReplicatedRegion = pointer(genesiscloud.Region(data.ReplicatedRegion.ValueString()))
-		}
-
 		response, err := r.client.CreateInstanceSnapshotWithResponse(ctx, instanceId, body)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", generateErrorMessage("create snapshot", err))
@@ -234,6 +286,12 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	// The snapshot is replicated once it is created
+	resp.Diagnostics.Append(data.PopulateReplicas(ctx, nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Trace(ctx, "created a snapshot resource")
 
 	// Save data into Terraform state
@@ -276,6 +334,10 @@ func (r *SnapshotResource) Create(ctx context.Context, req resource.CreateReques
 				return
 			}
 
+			if status == genesiscloud.SnapshotStatusCreated {
+				resp.Diagnostics.Append(r.replicate(ctx, &data, nil)...)
+			}
+
 			// Save data into Terraform state
 			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			if resp.Diagnostics.HasError() {
@@ -329,6 +391,21 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	discover, diag := req.Private.GetKey(ctx, snapshotDiscoverReplicasKey)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.readReplicas(ctx, &data, discover != nil)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if discover != nil {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, snapshotDiscoverReplicasKey, nil)...)
+	}
+
 	tflog.Trace(ctx, "read a snapshot resource")
 
 	// Save updated data into Terraform state
@@ -337,9 +414,11 @@ func (r *SnapshotResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data SnapshotResourceModel
+	var state SnapshotResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -378,6 +457,21 @@ func (r *SnapshotResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	if !data.ReplicatedRegion.Equal(state.ReplicatedRegion) {
+		current, diag := state.TrackedReplicas(ctx)
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(r.replicate(ctx, &data, current)...)
+		if resp.Diagnostics.HasError() {
+			// Save the replicas created so far into Terraform state
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "updated a snapshot resource")
 
 	// Save updated data into Terraform state
@@ -410,6 +504,19 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
+	var replicas []SnapshotReplicaModel
+	resp.Diagnostics.Append(data.Replicas.ElementsAs(ctx, &replicas, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, replica := range replicas {
+		resp.Diagnostics.Append(deleteSnapshot(ctx, r.client, replica.Id.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	response, err := r.client.DeleteSnapshotWithResponse(ctx, snapshotId)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("delete snapshot", err))
@@ -448,4 +555,141 @@ func (r *SnapshotResource) Delete(ctx context.Context, req resource.DeleteReques
 
 func (r *SnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// The replicas are discovered by the read following the import
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, snapshotDiscoverReplicasKey, []byte("true"))...)
+}
+
+// readReplicas refreshes the replicas of the snapshot. Only the replicas
+// created by this resource are tracked, as copies of the snapshot may be
+// managed elsewhere. A tracked replica is dropped once it is deleted, and the
+// replicated region is only removed once all of its replicas are deleted.
+// After an import the replicas are discovered in all other regions instead,
+// which also sets the replicated region.
+func (r *SnapshotResource) readReplicas(ctx context.Context, data *SnapshotResourceModel, discover bool) (diags diag.Diagnostics) {
+	if discover {
+		snapshots, diag := listSnapshots(ctx, r.client)
+		diags.Append(diag...)
+		if diags.HasError() {
+			return
+		}
+
+		region, replicas := discoverSnapshotReplicas(snapshots, data.Id.ValueString(), data.Region.ValueString())
+		if region != "" {
+			data.ReplicatedRegion = types.StringValue(region)
+		}
+
+		diags.Append(data.PopulateReplicas(ctx, replicas)...)
+		return
+	}
+
+	tracked, diag := data.TrackedReplicas(ctx)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return
+	}
+
+	replicas := make([]genesiscloud.Snapshot, 0, len(tracked))
+	for _, trackedReplica := range tracked {
+		replica, diag := findSnapshot(ctx, r.client, trackedReplica.Id)
+		diags.Append(diag...)
+		if diags.HasError() {
+			return
+		}
+
+		if replica != nil {
+			replicas = append(replicas, *replica)
+		}
+	}
+
+	// A deleted replica is created again by the next apply
+	if len(tracked) > 0 && len(replicas) == 0 {
+		data.ReplicatedRegion = types.StringNull()
+	}
+
+	diags.Append(data.PopulateReplicas(ctx, replicas)...)
+
+	return
+}
+
+// replicate deletes the current replicas in the regions which are not
+// replicated to anymore and clones the snapshot to the regions which were
+// added. The clones are tracked by the snapshots the API returns, as a new
+// snapshot is not listed right away. The replicas are populated even if the
+// replication fails, so the created ones are deleted with the snapshot.
+func (r *SnapshotResource) replicate(ctx context.Context, data *SnapshotResourceModel, current []genesiscloud.Snapshot) (diags diag.Diagnostics) {
+	var replicas []genesiscloud.Snapshot
+	defer func() {
+		diags.Append(data.PopulateReplicas(ctx, replicas)...)
+	}()
+
+	desired := map[string]bool{}
+	for _, region := range data.ReplicaRegions() {
+		desired[region] = true
+	}
+
+	existing := map[string]bool{}
+	for i, replica := range current {
+		region := string(replica.Region)
+
+		if desired[region] {
+			existing[region] = true
+			replicas = append(replicas, replica)
+			continue
+		}
+
+		tflog.Info(ctx, "deleting a snapshot replica", map[string]interface{}{"id": replica.Id, "region": region})
+
+		diags.Append(deleteSnapshot(ctx, r.client, replica.Id)...)
+		if diags.HasError() {
+			replicas = append(replicas, current[i:]...)
+			return
+		}
+	}
+
+	snapshotId := data.Id.ValueString()
+
+	for _, region := range data.ReplicaRegions() {
+		if existing[region] {
+			continue
+		}
+
+		tflog.Info(ctx, "replicating a snapshot", map[string]interface{}{"id": snapshotId, "region": region})
+
+		body := genesiscloud.CloneSnapshotJSONRequestBody{}
+		body.Name = data.Name.ValueString()
+		body.Region = genesiscloud.Region(region)
+
+		response, err := r.client.CloneSnapshotWithResponse(ctx, snapshotId, body)
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("replicate snapshot", err))
+			return
+		}
+
+		if response.JSON201 == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("replicate snapshot", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return
+		}
+
+		replicas = append(replicas, response.JSON201.Snapshot)
+	}
+
+	if !data.WaitForReplicas.ValueBool() {
+		return
+	}
+
+	created, diag := waitForSnapshotReplicas(ctx, r.client, replicas)
+	diags.Append(diag...)
+	if diags.HasError() {
+		// Keep track of the replicas, so they are deleted with the snapshot
+		return
+	}
+
+	replicas = created
+
+	return
 }
@@ -6,11 +6,14 @@ import (
 
 	"github.com/genesiscloud/genesiscloud-go"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-type SnapshotResourceModel struct {
+// SnapshotModel describes a snapshot as returned by the API. It is shared by
+// the snapshot resource and data sources.
+type SnapshotModel struct {
 	CreatedAt types.String `tfsdk:"created_at"`
 
 	// Id The unique ID of the snapshot.
@@ -28,25 +31,105 @@ type SnapshotResourceModel struct {
 	// SourceSnapshotId The id of the source snapshot from which this snapsot was derived.
 	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
 
-	// ReplicatedRegion The region identifier when the snapshot should be replicated.
-	ReplicatedRegion types.String `tfsdk:"replicated_region"`
-
 	// Size The storage size of this snapshot given in GiB.
 	Size types.Int64 `tfsdk:"size"`
 
 	// Status The snapshot status.
 	Status types.String `tfsdk:"status"`
+}
+
+// snapshotDiscoverReplicasKey is the private state key which marks an imported
+// snapshot, whose replicas are discovered by the next read.
+const snapshotDiscoverReplicasKey = "discover_replicas"
+
+type SnapshotResourceModel struct {
+	SnapshotModel
+
+	// ReplicatedRegion The region identifier when the snapshot should be replicated.
+	ReplicatedRegion types.String `tfsdk:"replicated_region"`
+
+	// Replicas The replicas of the snapshot in other regions.
+	Replicas types.List `tfsdk:"replicas"`
 
 	// Internal
 
 	// RetainOnDelete Flag to retain the snapshot when the resource is deleted. It has to be deleted manually.
 	RetainOnDelete types.Bool `tfsdk:"retain_on_delete"`
 
+	// WaitForReplicas Flag to wait until the replicas are created.
+	WaitForReplicas types.Bool `tfsdk:"wait_for_replicas"`
+
 	// Timeouts The resource timeouts
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
-func (data *SnapshotResourceModel) PopulateFromClientResponse(ctx context.Context, snapshot *genesiscloud.Snapshot) (diag diag.Diagnostics) {
+type SnapshotReplicaModel struct {
+	// Id The unique ID of the replica snapshot.
+	Id types.String `tfsdk:"id"`
+
+	// Region The region identifier of the replica.
+	Region types.String `tfsdk:"region"`
+
+	// Status The status of the replica snapshot.
+	Status types.String `tfsdk:"status"`
+}
+
+var snapshotReplicaAttrTypes = map[string]attr.Type{
+	"id":     types.StringType,
+	"region": types.StringType,
+	"status": types.StringType,
+}
+
+// ReplicaRegions returns the regions the snapshot is replicated to.
+func (data *SnapshotResourceModel) ReplicaRegions() []string {
+	if data.ReplicatedRegion.IsNull() || data.ReplicatedRegion.IsUnknown() {
+		return nil
+	}
+
+	return []string{data.ReplicatedRegion.ValueString()}
+}
+
+// TrackedReplicas returns the replicas recorded in the state.
+func (data *SnapshotResourceModel) TrackedReplicas(ctx context.Context) ([]genesiscloud.Snapshot, diag.Diagnostics) {
+	if data.Replicas.IsNull() || data.Replicas.IsUnknown() {
+		return nil, nil
+	}
+
+	var models []SnapshotReplicaModel
+	diags := data.Replicas.ElementsAs(ctx, &models, true)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	replicas := make([]genesiscloud.Snapshot, 0, len(models))
+	for _, model := range models {
+		replicas = append(replicas, genesiscloud.Snapshot{
+			Id:     model.Id.ValueString(),
+			Region: genesiscloud.Region(model.Region.ValueString()),
+			Status: genesiscloud.SnapshotStatus(model.Status.ValueString()),
+		})
+	}
+
+	return replicas, diags
+}
+
+func (data *SnapshotResourceModel) PopulateReplicas(ctx context.Context, replicas []genesiscloud.Snapshot) (diags diag.Diagnostics) {
+	models := make([]SnapshotReplicaModel, 0, len(replicas))
+
+	for _, replica := range replicas {
+		models = append(models, SnapshotReplicaModel{
+			Id:     types.StringValue(replica.Id),
+			Region: types.StringValue(string(replica.Region)),
+			Status: types.StringValue(string(replica.Status)),
+		})
+	}
+
+	data.Replicas, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: snapshotReplicaAttrTypes}, models)
+
+	return
+}
+
+func (data *SnapshotModel) PopulateFromClientResponse(ctx context.Context, snapshot *genesiscloud.Snapshot) (diag diag.Diagnostics) {
 	data.CreatedAt = types.StringValue(snapshot.CreatedAt.Format(time.RFC3339))
 	data.Id = types.StringValue(snapshot.Id)
 	data.Name = types.StringValue(snapshot.Name)
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/datasourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ datasource.DataSource              = &SnapshotsDataSource{}
+	_ datasource.DataSourceWithConfigure = &SnapshotsDataSource{}
+)
+
+func NewSnapshotsDataSource() datasource.DataSource {
+	return &SnapshotsDataSource{}
+}
+
+// SnapshotsDataSource defines the data source implementation.
+type SnapshotsDataSource struct {
+	DataSourceWithClient
+	DataSourceWithTimeout
+}
+
+func (d *SnapshotsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_snapshots"
+}
+
+// snapshotsFilterAttribute returns the filter attribute shared by the snapshot data sources.
+func snapshotsFilterAttribute(ctx context.Context) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Only snapshots matching all filters are returned.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"created_after": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by the minimum creation time in RFC 3339.",
+				Optional:            true,
+				Validators: []validator.String{
+					rfc3339Validator{},
+				},
+			}),
+			"name_regex": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by a regular expression matching the name, e.g. `^base-`.",
+				Optional:            true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			}),
+			"region": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by the region identifier.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
+				},
+			}),
+			"source_instance_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by the id of the source instance.",
+				Optional:            true,
+			}),
+			"source_snapshot_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by the id of the source snapshot.",
+				Optional:            true,
+			}),
+			"status": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "Filter by the snapshot status, e.g. `created`.",
+				Optional:            true,
+			}),
+		},
+	}
+}
+
+// snapshotAttributes returns the computed attributes of a snapshot shared by the snapshot data sources.
+func snapshotAttributes(ctx context.Context) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"created_at": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The timestamp when this snapshot was created in RFC 3339.",
+			Computed:            true,
+		}),
+		"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The unique ID of the snapshot.",
+			Computed:            true,
+		}),
+		"name": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The human-readable name for the snapshot.",
+			Computed:            true,
+		}),
+		"region": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The region identifier.",
+			Computed:            true,
+		}),
+		"size": datasourceenhancer.Attribute(ctx, schema.Int64Attribute{
+			MarkdownDescription: "The storage size of this snapshot given in GiB.",
+			Computed:            true,
+		}),
+		"source_instance_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The id of the source instance from which this snapshot was derived.",
+			Computed:            true,
+		}),
+		"source_snapshot_id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The id of the source snapshot from which this snapshot was derived.",
+			Computed:            true,
+		}),
+		"status": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+			MarkdownDescription: "The snapshot status.",
+			Computed:            true,
+		}),
+	}
+}
+
+func (d *SnapshotsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Snapshots data source",
+
+		Attributes: map[string]schema.Attribute{
+			"filter": snapshotsFilterAttribute(ctx),
+			"snapshots": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching snapshots, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: snapshotAttributes(ctx),
+				},
+			},
+			"id": datasourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The ID of the data source itself.",
+				Computed:            true,
+			}),
+
+			// Internal
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *SnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SnapshotsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := d.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	match, diag := data.Filter.Matcher()
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshots, diag := listSnapshots(ctx, d.client)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Snapshots = []SnapshotModel{}
+
+	for _, snapshot := range filterSnapshots(snapshots, match) {
+		model := SnapshotModel{}
+		resp.Diagnostics.Append(model.PopulateFromClientResponse(ctx, &snapshot)...)
+
+		data.Snapshots = append(data.Snapshots, model)
+	}
+
+	data.Id = types.StringValue("none")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SnapshotsFilterDataSourceModel struct {
+	// CreatedAfter Filter by the minimum creation time in RFC 3339.
+	CreatedAfter types.String `tfsdk:"created_after"`
+
+	// NameRegex Filter by a regular expression matching the name.
+	NameRegex types.String `tfsdk:"name_regex"`
+
+	// Region Filter by the region identifier.
+	Region types.String `tfsdk:"region"`
+
+	// SourceInstanceId Filter by the id of the source instance.
+	SourceInstanceId types.String `tfsdk:"source_instance_id"`
+
+	// SourceSnapshotId Filter by the id of the source snapshot.
+	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
+
+	// Status Filter by the snapshot status.
+	Status types.String `tfsdk:"status"`
+}
+
+// SnapshotsDataSourceModel describes the data source data model.
+type SnapshotsDataSourceModel struct {
+	Filter    *SnapshotsFilterDataSourceModel `tfsdk:"filter"`
+	Snapshots []SnapshotModel                 `tfsdk:"snapshots"`
+	Id        types.String                    `tfsdk:"id"` // placeholder
+
+	// Internal
+
+	// Timeouts The data source timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// SnapshotDataSourceModel describes the data source data model.
+type SnapshotDataSourceModel struct {
+	SnapshotModel
+
+	Filter *SnapshotsFilterDataSourceModel `tfsdk:"filter"`
+
+	// MostRecent Flag to return the newest snapshot if several snapshots match.
+	MostRecent types.Bool `tfsdk:"most_recent"`
+
+	// Internal
+
+	// Timeouts The data source timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Matcher returns a function which reports whether a snapshot matches all
+// configured filters. A nil filter matches all snapshots.
+func (data *SnapshotsFilterDataSourceModel) Matcher() (match func(snapshot genesiscloud.Snapshot) bool, diags diag.Diagnostics) {
+	if data == nil {
+		return func(genesiscloud.Snapshot) bool { return true }, diags
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("name_regex"), "Invalid Regular Expression", err.Error())
+		}
+	}
+
+	var createdAfter time.Time
+	if !data.CreatedAfter.IsNull() {
+		var err error
+		createdAfter, err = time.Parse(time.RFC3339, data.CreatedAfter.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter").AtName("created_after"), "Invalid Timestamp", err.Error())
+		}
+	}
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	match = func(snapshot genesiscloud.Snapshot) bool {
+		if nameRegex != nil && !nameRegex.MatchString(snapshot.Name) {
+			return false
+		}
+
+		if !data.Region.IsNull() && string(snapshot.Region) != data.Region.ValueString() {
+			return false
+		}
+
+		if !data.Status.IsNull() && string(snapshot.Status) != data.Status.ValueString() {
+			return false
+		}
+
+		if !data.SourceInstanceId.IsNull() &&
+			(snapshot.SourceInstanceId == nil || *snapshot.SourceInstanceId != data.SourceInstanceId.ValueString()) {
+			return false
+		}
+
+		if !data.SourceSnapshotId.IsNull() &&
+			(snapshot.SourceSnapshotId == nil || *snapshot.SourceSnapshotId != data.SourceSnapshotId.ValueString()) {
+			return false
+		}
+
+		if !createdAfter.IsZero() && snapshot.CreatedAt.Before(createdAfter) {
+			return false
+		}
+
+		return true
+	}
+
+	return match, diags
+}
+
+// filterSnapshots returns the matching snapshots, newest first.
+func filterSnapshots(snapshots []genesiscloud.Snapshot, match func(snapshot genesiscloud.Snapshot) bool) []genesiscloud.Snapshot {
+	var result []genesiscloud.Snapshot
+
+	for _, snapshot := range snapshots {
+		if match(snapshot) {
+			result = append(result, snapshot)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSnapshotsFilterMatcher(t *testing.T) {
+	created := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	snapshots := []genesiscloud.Snapshot{
+		{Id: "old", Name: "base-old", CreatedAt: created.Add(-48 * time.Hour), Region: "NORD-NO-KRS-1", Status: genesiscloud.SnapshotStatusCreated, SourceInstanceId: pointer("instance")},
+		{Id: "new", Name: "base-new", CreatedAt: created, Region: "NORD-NO-KRS-1", Status: genesiscloud.SnapshotStatusCreated, SourceInstanceId: pointer("instance")},
+		{Id: "replica", Name: "base-new", CreatedAt: created.Add(time.Hour), Region: "EUC-DE-MUC-1", Status: genesiscloud.SnapshotStatusError, SourceSnapshotId: pointer("new")},
+		{Id: "manual", Name: "manual", CreatedAt: created.Add(-time.Hour), Region: "NORD-NO-KRS-1", Status: genesiscloud.SnapshotStatusCreated, SourceInstanceId: pointer("other")},
+	}
+
+	tests := []struct {
+		name   string
+		filter *SnapshotsFilterDataSourceModel
+		ids    string
+	}{
+		{"nil", nil, "replica,new,manual,old"},
+		{"name regex", &SnapshotsFilterDataSourceModel{NameRegex: types.StringValue("^base-")}, "replica,new,old"},
+		{"region", &SnapshotsFilterDataSourceModel{Region: types.StringValue("NORD-NO-KRS-1")}, "new,manual,old"},
+		{"status", &SnapshotsFilterDataSourceModel{Status: types.StringValue("created")}, "new,manual,old"},
+		{"source instance", &SnapshotsFilterDataSourceModel{SourceInstanceId: types.StringValue("instance")}, "new,old"},
+		{"source snapshot", &SnapshotsFilterDataSourceModel{SourceSnapshotId: types.StringValue("new")}, "replica"},
+		{"created after", &SnapshotsFilterDataSourceModel{CreatedAfter: types.StringValue("2024-03-10T11:30:00Z")}, "replica,new"},
+		{"combined", &SnapshotsFilterDataSourceModel{
+			NameRegex:        types.StringValue("^base-"),
+			SourceInstanceId: types.StringValue("instance"),
+			CreatedAfter:     types.StringValue("2024-03-09T00:00:00Z"),
+		}, "new"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			match, diags := test.filter.Matcher()
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if ids := snapshotIds(filterSnapshots(snapshots, match)); ids != test.ids {
+				t.Errorf("expected %q, got %q", test.ids, ids)
+			}
+		})
+	}
+}
+
+func TestSnapshotsFilterMatcherInvalid(t *testing.T) {
+	filter := &SnapshotsFilterDataSourceModel{
+		CreatedAfter:     types.StringValue("yesterday"),
+		NameRegex:        types.StringValue("("),
+		Region:           types.StringNull(),
+		SourceInstanceId: types.StringNull(),
+		SourceSnapshotId: types.StringNull(),
+		Status:           types.StringNull(),
+	}
+
+	_, diags := filter.Matcher()
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("expected 2 errors, got %v", diags)
+	}
+}
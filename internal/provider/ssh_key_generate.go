@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	SSHKeyAlgorithmED25519 = "ed25519"
+	SSHKeyAlgorithmRSA     = "rsa"
+
+	// defaultSSHKeyRSABits is the size of generated RSA keys if none is configured.
+	defaultSSHKeyRSABits = 4096
+)
+
+var (
+	sshKeyAlgorithms = []string{SSHKeyAlgorithmED25519, SSHKeyAlgorithmRSA}
+	sshKeyRSABits    = []int64{2048, 3072, 4096}
+)
+
+// generateSSHKeyPair generates a key pair and returns the public key in
+// authorized_keys format and the private key in OpenSSH format.
+func generateSSHKeyPair(algorithm string, rsaBits int) (publicKey string, privateKey string, err error) {
+	var key crypto.Signer
+
+	switch algorithm {
+	case SSHKeyAlgorithmED25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case SSHKeyAlgorithmRSA:
+		key, err = rsa.GenerateKey(rand.Reader, rsaBits)
+	default:
+		err = fmt.Errorf("unsupported algorithm %q", algorithm)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return "", "", err
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKey = strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(sshPublicKey)), "\n")
+	privateKey = string(pem.EncodeToMemory(block))
+
+	return publicKey, privateKey, nil
+}
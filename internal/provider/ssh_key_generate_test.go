@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"bytes"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateSSHKeyPair(t *testing.T) {
+	testCases := map[string]struct {
+		algorithm string
+		rsaBits   int
+		keyType   string
+	}{
+		"ed25519": {
+			algorithm: SSHKeyAlgorithmED25519,
+			keyType:   ssh.KeyAlgoED25519,
+		},
+		"rsa": {
+			algorithm: SSHKeyAlgorithmRSA,
+			rsaBits:   2048,
+			keyType:   ssh.KeyAlgoRSA,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			publicKey, privateKey, err := generateSSHKeyPair(testCase.algorithm, testCase.rsaBits)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			parsedPublicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+			if err != nil {
+				t.Fatalf("parsing public key: %s", err)
+			}
+
+			if parsedPublicKey.Type() != testCase.keyType {
+				t.Errorf("expected a %s key, got %s", testCase.keyType, parsedPublicKey.Type())
+			}
+
+			signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+			if err != nil {
+				t.Fatalf("parsing private key: %s", err)
+			}
+
+			if !bytes.Equal(signer.PublicKey().Marshal(), parsedPublicKey.Marshal()) {
+				t.Errorf("the private key does not match the public key")
+			}
+		})
+	}
+
+	if _, _, err := generateSSHKeyPair("dsa", 0); err == nil {
+		t.Errorf("expected an error for an unsupported algorithm")
+	}
+}
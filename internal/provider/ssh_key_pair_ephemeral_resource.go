@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/ephemeralenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ ephemeral.EphemeralResource = &SSHKeyPairEphemeralResource{}
+)
+
+func NewSSHKeyPairEphemeralResource() ephemeral.EphemeralResource {
+	return &SSHKeyPairEphemeralResource{}
+}
+
+// SSHKeyPairEphemeralResource defines the ephemeral resource implementation.
+type SSHKeyPairEphemeralResource struct{}
+
+func (r *SSHKeyPairEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key_pair"
+}
+
+func (r *SSHKeyPairEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "SSH key pair ephemeral resource. Generates a key pair which is never stored in the plan or state. " +
+			"Pass the `public_key_openssh` to the `public_key_wo` of a `genesiscloud_ssh_key`. " +
+			"A new key pair is generated in every Terraform run, so the private key only matches the uploaded key in the run which uploaded it.",
+
+		Attributes: map[string]schema.Attribute{
+			"algorithm": ephemeralenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The algorithm of the key pair. Defaults to `%s`.", SSHKeyAlgorithmED25519),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(sshKeyAlgorithms...),
+				},
+			}),
+			"private_key_openssh": ephemeralenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The private key in OpenSSH format.",
+				Computed:            true,
+				Sensitive:           true,
+			}),
+			"public_key_openssh": ephemeralenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The public key in authorized_keys format.",
+				Computed:            true,
+			}),
+			"rsa_bits": ephemeralenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The size of the key if the `algorithm` is `%s`. Defaults to `%d`.", SSHKeyAlgorithmRSA, defaultSSHKeyRSABits),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(sshKeyRSABits...),
+				},
+			}),
+		},
+	}
+}
+
+func (r *SSHKeyPairEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data SSHKeyPairEphemeralResourceModel
+
+	// Read Terraform config data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	generate := SSHKeyGenerateModel{
+		Algorithm: data.Algorithm,
+		RsaBits:   data.RsaBits,
+	}
+
+	publicKey, privateKey, err := generateSSHKeyPair(generate.AlgorithmValue(), generate.RsaBitsValue())
+	if err != nil {
+		resp.Diagnostics.AddError("Key Generation Error", generateErrorMessage("generate ssh_key_pair", err))
+		return
+	}
+
+	data.Algorithm = types.StringValue(generate.AlgorithmValue())
+	data.PrivateKeyOpenssh = types.StringValue(privateKey)
+	data.PublicKeyOpenssh = types.StringValue(publicKey)
+
+	if data.Algorithm.ValueString() == SSHKeyAlgorithmRSA {
+		data.RsaBits = types.Int64Value(int64(generate.RsaBitsValue()))
+	}
+
+	tflog.Trace(ctx, "opened a ssh_key_pair ephemeral resource")
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
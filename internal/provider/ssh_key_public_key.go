@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeyMinRSABits is the minimum size of RSA public keys.
+const sshKeyMinRSABits = 2048
+
+// parseSSHPublicKey parses a public key in authorized_keys format and rejects
+// weak keys. Surrounding whitespace and the comment are ignored.
+func parseSSHPublicKey(value string) (ssh.PublicKey, error) {
+	publicKey, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(value)))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, fmt.Errorf("expected a single key")
+	}
+
+	switch publicKey.Type() {
+	case ssh.KeyAlgoDSA:
+		return nil, fmt.Errorf("DSA keys are not supported")
+	case ssh.KeyAlgoRSA:
+		cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unexpected RSA key")
+		}
+
+		rsaPublicKey, ok := cryptoPublicKey.CryptoPublicKey().(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unexpected RSA key")
+		}
+
+		if bits := rsaPublicKey.N.BitLen(); bits < sshKeyMinRSABits {
+			return nil, fmt.Errorf("the RSA key has %d bits, at least %d bits are required", bits, sshKeyMinRSABits)
+		}
+	}
+
+	return publicKey, nil
+}
+
+// sshPublicKeysEqual reports whether both values contain the same key, ignoring
+// whitespace and comments. Values which cannot be parsed are compared as strings.
+func sshPublicKeysEqual(a string, b string) bool {
+	keyA, errA := parseSSHPublicKey(a)
+	keyB, errB := parseSSHPublicKey(b)
+	if errA != nil || errB != nil {
+		return strings.TrimSpace(a) == strings.TrimSpace(b)
+	}
+
+	return keyA.Type() == keyB.Type() && string(keyA.Marshal()) == string(keyB.Marshal())
+}
+
+var _ basetypes.StringTypable = SSHPublicKeyType{}
+
+// SSHPublicKeyType is a string type for public keys in authorized_keys format.
+type SSHPublicKeyType struct {
+	basetypes.StringType
+}
+
+func (t SSHPublicKeyType) Equal(o attr.Type) bool {
+	other, ok := o.(SSHPublicKeyType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t SSHPublicKeyType) String() string {
+	return "SSHPublicKeyType"
+}
+
+func (t SSHPublicKeyType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return SSHPublicKeyValue{StringValue: in}, nil
+}
+
+func (t SSHPublicKeyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return SSHPublicKeyValue{StringValue: stringValue}, nil
+}
+
+func (t SSHPublicKeyType) ValueType(ctx context.Context) attr.Value {
+	return SSHPublicKeyValue{}
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = SSHPublicKeyValue{}
+
+// SSHPublicKeyValue is a public key in authorized_keys format. Values with the
+// same key are semantically equal, so whitespace and the comment are kept as
+// configured instead of being reported as a difference.
+type SSHPublicKeyValue struct {
+	basetypes.StringValue
+}
+
+// NewSSHPublicKeyValue returns a known public key value.
+func NewSSHPublicKeyValue(value string) SSHPublicKeyValue {
+	return SSHPublicKeyValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v SSHPublicKeyValue) Equal(o attr.Value) bool {
+	other, ok := o.(SSHPublicKeyValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v SSHPublicKeyValue) Type(ctx context.Context) attr.Type {
+	return SSHPublicKeyType{}
+}
+
+func (v SSHPublicKeyValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(SSHPublicKeyValue)
+	if !ok {
+		diags.AddError("Semantic Equality Check Error", fmt.Sprintf("Expected value type %T, got %T.", v, newValuable))
+		return false, diags
+	}
+
+	return sshPublicKeysEqual(v.ValueString(), newValue.ValueString()), diags
+}
+
+var _ planmodifier.String = sshPublicKeyRequiresReplace{}
+
+// sshPublicKeyRequiresReplace requires the replacement of the resource if the
+// key changes. Changes of whitespace or the comment do not replace it.
+type sshPublicKeyRequiresReplace struct{}
+
+func (m sshPublicKeyRequiresReplace) Description(ctx context.Context) string {
+	return "If the key of this attribute changes, the resource will be replaced. Changes of whitespace or the comment do not replace it."
+}
+
+func (m sshPublicKeyRequiresReplace) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m sshPublicKeyRequiresReplace) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsUnknown() || req.StateValue.IsNull() {
+		return
+	}
+
+	if req.PlanValue.IsNull() || !sshPublicKeysEqual(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		resp.RequiresReplace = true
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+const otherSamplePublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAICTjC4O8vBE+GsXzF8iDHdjnOegFA4dEbVKD4wVpwF+G bob@example.com"
+
+// testRSAPublicKey generates an RSA public key with the given size in authorized_keys format.
+func testRSAPublicKey(t *testing.T, bits int) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+
+	publicKey, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("converting key: %s", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+}
+
+func TestParseSSHPublicKey(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		valid bool
+	}{
+		"ed25519":         {value: samplePublicKey, valid: true},
+		"trailing space":  {value: samplePublicKey + "\n", valid: true},
+		"without comment": {value: strings.TrimSuffix(samplePublicKey, " alice@example.com"), valid: true},
+		"rsa 2048":        {value: testRSAPublicKey(t, 2048), valid: true},
+		"rsa 1024":        {value: testRSAPublicKey(t, 1024), valid: false},
+		"multiple keys":   {value: samplePublicKey + "\n" + otherSamplePublicKey, valid: false},
+		"invalid":         {value: "ssh-ed25519 invalid", valid: false},
+		"empty":           {value: "", valid: false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseSSHPublicKey(testCase.value)
+			if testCase.valid && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if !testCase.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestSSHPublicKeysEqual(t *testing.T) {
+	testCases := map[string]struct {
+		a, b  string
+		equal bool
+	}{
+		"identical":       {a: samplePublicKey, b: samplePublicKey, equal: true},
+		"trailing space":  {a: samplePublicKey, b: samplePublicKey + "\n", equal: true},
+		"other comment":   {a: samplePublicKey, b: strings.Replace(samplePublicKey, "alice@", "bob@", 1), equal: true},
+		"other key":       {a: samplePublicKey, b: otherSamplePublicKey, equal: false},
+		"invalid":         {a: samplePublicKey, b: "invalid", equal: false},
+		"invalid spacing": {a: "invalid", b: "invalid\n", equal: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if actual := sshPublicKeysEqual(testCase.a, testCase.b); actual != testCase.equal {
+				t.Errorf("expected %t, got %t", testCase.equal, actual)
+			}
+		})
+	}
+}
+
+func TestSSHPublicKeyValueSemanticEquals(t *testing.T) {
+	testCases := map[string]struct {
+		newValue basetypes.StringValuable
+		equal    bool
+		err      bool
+	}{
+		"identical":     {newValue: NewSSHPublicKeyValue(samplePublicKey), equal: true},
+		"other comment": {newValue: NewSSHPublicKeyValue(strings.Replace(samplePublicKey, "alice@", "bob@", 1) + "\n"), equal: true},
+		"no comment":    {newValue: NewSSHPublicKeyValue(strings.Join(strings.Fields(samplePublicKey)[:2], " ")), equal: true},
+		"other key":     {newValue: NewSSHPublicKeyValue(otherSamplePublicKey), equal: false},
+		"other type":    {newValue: types.StringValue(samplePublicKey), err: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			equal, diags := NewSSHPublicKeyValue(samplePublicKey).StringSemanticEquals(context.Background(), testCase.newValue)
+
+			if diags.HasError() != testCase.err {
+				t.Fatalf("expected error %t, got %v", testCase.err, diags)
+			}
+			if equal != testCase.equal {
+				t.Errorf("expected %t, got %t", testCase.equal, equal)
+			}
+		})
+	}
+}
+
+func TestSSHPublicKeyTypeValueFromTerraform(t *testing.T) {
+	value, err := SSHPublicKeyType{}.ValueFromTerraform(context.Background(), tftypes.NewValue(tftypes.String, samplePublicKey))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !value.Equal(NewSSHPublicKeyValue(samplePublicKey)) {
+		t.Errorf("expected the public key value, got %#v", value)
+	}
+}
+
+func TestSSHPublicKeyRequiresReplace(t *testing.T) {
+	raw := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{})
+	null := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil)
+
+	testCases := map[string]struct {
+		stateRaw        tftypes.Value
+		stateValue      types.String
+		planValue       types.String
+		requiresReplace bool
+	}{
+		"create": {
+			stateRaw:   null,
+			stateValue: types.StringNull(),
+			planValue:  types.StringValue(samplePublicKey),
+		},
+		"unchanged": {
+			stateRaw:   raw,
+			stateValue: types.StringValue(samplePublicKey),
+			planValue:  types.StringValue(samplePublicKey),
+		},
+		"comment changed": {
+			stateRaw:   raw,
+			stateValue: types.StringValue(samplePublicKey),
+			planValue:  types.StringValue(strings.Replace(samplePublicKey, "alice@", "bob@", 1) + "\n"),
+		},
+		"unknown": {
+			stateRaw:   raw,
+			stateValue: types.StringValue(samplePublicKey),
+			planValue:  types.StringUnknown(),
+		},
+		"key changed": {
+			stateRaw:        raw,
+			stateValue:      types.StringValue(samplePublicKey),
+			planValue:       types.StringValue(testRSAPublicKey(t, 2048)),
+			requiresReplace: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				State:      tfsdk.State{Raw: testCase.stateRaw},
+				Plan:       tfsdk.Plan{Raw: raw},
+				StateValue: testCase.stateValue,
+				PlanValue:  testCase.planValue,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: testCase.planValue}
+
+			sshPublicKeyRequiresReplace{}.PlanModifyString(context.Background(), req, resp)
+
+			if resp.RequiresReplace != testCase.requiresReplace {
+				t.Errorf("expected requires replace %t, got %t", testCase.requiresReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
@@ -2,16 +2,27 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/genesiscloud/genesiscloud-go"
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ssh"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
@@ -19,6 +30,9 @@ var (
 	_ resource.Resource                = &SSHKeyResource{}
 	_ resource.ResourceWithConfigure   = &SSHKeyResource{}
 	_ resource.ResourceWithImportState = &SSHKeyResource{}
+
+	_ resource.ResourceWithConfigValidators = &SSHKeyResource{}
+	_ resource.ResourceWithModifyPlan       = &SSHKeyResource{}
 )
 
 func NewSSHKeyResource() resource.Resource {
@@ -49,12 +63,38 @@ func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			}),
 			"fingerprint": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "The fingerprint of the SSH key.",
+				MarkdownDescription: "The SHA256 fingerprint of the SSH key. Known at plan time unless the key pair is generated with `generate`.",
 				Computed:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(), // immutable
 				},
 			}),
+			"generate": schema.SingleNestedAttribute{
+				MarkdownDescription: "Option to generate the key pair in the provider and upload its public key. " +
+					"The private key is stored in the state as `private_key_openssh`. " +
+					"To keep the private key out of the state, use the `genesiscloud_ssh_key_pair` ephemeral resource with `public_key_wo` instead. " +
+					"If the value of this attribute changes, the resource will be replaced.",
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"algorithm": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("The algorithm of the key pair. Defaults to `%s`.", SSHKeyAlgorithmED25519),
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(sshKeyAlgorithms...),
+						},
+					}),
+					"rsa_bits": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+						MarkdownDescription: fmt.Sprintf("The size of the key if the `algorithm` is `%s`. Defaults to `%d`.", SSHKeyAlgorithmRSA, defaultSSHKeyRSABits),
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.OneOf(sshKeyRSABits...),
+						},
+					}),
+				},
+			},
 			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the SSH key.",
 				Computed:            true,
@@ -66,11 +106,41 @@ func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "The human-readable name for the SSH key.",
 				Required:            true,
 			}),
+			"private_key_openssh": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The generated private key in OpenSSH format. Only set if the key pair is generated with `generate`.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
 			"public_key": resourceenhancer.Attribute(ctx, schema.StringAttribute{
-				MarkdownDescription: "SSH public key.",
-				Required:            true,
+				MarkdownDescription: "SSH public key. Exactly one of `public_key`, `public_key_wo` or `generate` must be provided.",
+				CustomType:          SSHPublicKeyType{},
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					sshPublicKeyRequiresReplace{},
+				},
+				Validators: []validator.String{
+					sshPublicKeyValidator{},
+				},
+			}),
+			"public_key_wo": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "SSH public key which is not stored in the plan, e.g. the `public_key_openssh` of a `genesiscloud_ssh_key_pair` ephemeral resource. " +
+					"Requires `public_key_wo_version` and Terraform 1.11 or later.",
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					sshPublicKeyValidator{},
+				},
+			}),
+			"public_key_wo_version": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
+				MarkdownDescription: "The version of `public_key_wo`. Change it to upload a new `public_key_wo`.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
 				},
 			}),
 
@@ -80,6 +150,65 @@ func (r *SSHKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *SSHKeyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("public_key"),
+			path.MatchRoot("public_key_wo"),
+			path.MatchRoot("generate"),
+		),
+		resourcevalidator.RequiredTogether(
+			path.MatchRoot("public_key_wo"),
+			path.MatchRoot("public_key_wo_version"),
+		),
+	}
+}
+
+func (r *SSHKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SSHKeyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The fingerprint is only predicted for new keys, existing keys keep theirs
+	if !plan.Fingerprint.IsUnknown() {
+		return
+	}
+
+	publicKey := plan.PublicKey.StringValue
+
+	// Write-only attributes are only available in the config
+	var publicKeyWo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &publicKeyWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !publicKeyWo.IsNull() {
+		publicKey = publicKeyWo
+	}
+
+	if publicKey.IsNull() || publicKey.IsUnknown() {
+		return
+	}
+
+	key, err := parseSSHPublicKey(publicKey.ValueString())
+	if err != nil {
+		// The validators report invalid keys
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fingerprint"), ssh.FingerprintSHA256(key))...)
+}
+
 func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SSHKeyResourceModel
 
@@ -99,7 +228,31 @@ func (r *SSHKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	body := genesiscloud.CreateSSHKeyJSONRequestBody{}
 
 	body.Name = data.Name.ValueString()
-	body.Value = data.PublicKey.ValueString()
+
+	// Write-only attributes are only available in the config
+	var publicKeyWo types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("public_key_wo"), &publicKeyWo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.PrivateKeyOpenssh = types.StringNull()
+
+	switch {
+	case data.Generate != nil:
+		publicKey, privateKey, err := generateSSHKeyPair(data.Generate.AlgorithmValue(), data.Generate.RsaBitsValue())
+		if err != nil {
+			resp.Diagnostics.AddError("Key Generation Error", generateErrorMessage("generate ssh_key", err))
+			return
+		}
+
+		body.Value = publicKey
+		data.PrivateKeyOpenssh = types.StringValue(privateKey)
+	case !publicKeyWo.IsNull():
+		body.Value = publicKeyWo.ValueString()
+	default:
+		body.Value = data.PublicKey.ValueString()
+	}
 
 	response, err := r.client.CreateSSHKeyWithResponse(ctx, body)
 	if err != nil {
@@ -258,5 +411,76 @@ func (r *SSHKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *SSHKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateComposite(ctx, req, resp, map[string]importResolver{
+		"fingerprint": func(ctx context.Context, value string) ([]importCandidate, diag.Diagnostics) {
+			// The SHA256 fingerprint is stored in the state, the one of the API is accepted too
+			return r.findSSHKeys(ctx, func(sshKey genesiscloud.SSHKey) bool {
+				return sshKeyFingerprint(sshKey) == value || sshKey.Fingerprint == value
+			})
+		},
+		"name": func(ctx context.Context, value string) ([]importCandidate, diag.Diagnostics) {
+			return r.findSSHKeys(ctx, func(sshKey genesiscloud.SSHKey) bool {
+				return sshKey.Name == value
+			})
+		},
+	})
+}
+
+// findSSHKeys returns the SSH keys of the account matching the filter.
+func (r *SSHKeyResource) findSSHKeys(ctx context.Context, filter func(sshKey genesiscloud.SSHKey) bool) ([]importCandidate, diag.Diagnostics) {
+	sshKeys, diags := listSSHKeys(ctx, r.client)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	var candidates []importCandidate
+
+	for _, sshKey := range sshKeys {
+		if !filter(sshKey) {
+			continue
+		}
+
+		candidates = append(candidates, importCandidate{
+			Id:          sshKey.Id,
+			Description: fmt.Sprintf("name %q, fingerprint %s, created at %s", sshKey.Name, sshKeyFingerprint(sshKey), sshKey.CreatedAt.Format(time.RFC3339)),
+		})
+	}
+
+	return candidates, diags
+}
+
+// listSSHKeys returns all SSH keys of the account.
+func listSSHKeys(ctx context.Context, client *Client) ([]genesiscloud.SSHKey, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var sshKeys []genesiscloud.SSHKey
+
+	for page := 1; ; page++ {
+		response, err := client.ListSSHKeysWithResponse(ctx, &genesiscloud.ListSSHKeysParams{
+			Page:    pointer(page),
+			PerPage: pointer(100),
+		})
+		if err != nil {
+			diags.AddError("Client Error", generateErrorMessage("read ssh_keys", err))
+			return nil, diags
+		}
+
+		sshKeysResponse := response.JSON200
+		if sshKeysResponse == nil {
+			diags.AddError("Client Error", generateClientErrorMessage("read ssh_keys", ErrorResponse{
+				Body:         response.Body,
+				HTTPResponse: response.HTTPResponse,
+				Error:        response.JSONDefault,
+			}))
+			return nil, diags
+		}
+
+		sshKeys = append(sshKeys, sshKeysResponse.SshKeys...)
+
+		if len(sshKeysResponse.SshKeys) < 100 {
+			// pagination done
+			break
+		}
+	}
+
+	return sshKeys, diags
 }
@@ -38,6 +38,13 @@ func TestAccSSHKeyResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name testing
+			{
+				ResourceName:      "genesiscloud_ssh_key.test",
+				ImportState:       true,
+				ImportStateId:     "name:one",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: providerConfig + testAccSSHKeyResourceConfig("two", samplePublicKey),
@@ -49,3 +56,41 @@ func TestAccSSHKeyResource(t *testing.T) {
 		},
 	})
 }
+
+func testAccSSHKeyResourceGenerateConfig(name, algorithm string) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_ssh_key" "test" {
+  name = %[1]q
+
+  generate = {
+    algorithm = %[2]q
+  }
+}
+`, name, algorithm)
+}
+
+func TestAccSSHKeyResourceGenerate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSSHKeyResourceGenerateConfig("one", SSHKeyAlgorithmED25519),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_ssh_key.test", "name", "one"),
+					resource.TestCheckResourceAttrSet("genesiscloud_ssh_key.test", "public_key"),
+					resource.TestCheckResourceAttrSet("genesiscloud_ssh_key.test", "private_key_openssh"),
+				),
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccSSHKeyResourceGenerateConfig("two", SSHKeyAlgorithmED25519),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_ssh_key.test", "name", "two"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshKeySetParallelism is the number of API calls run at the same time to reconcile a set of SSH keys.
+const sshKeySetParallelism = 4
+
+// parseAuthorizedKeys parses text in authorized_keys format and returns a map
+// of name to public key. The comment of a key is its name, keys without a
+// comment are named by their fingerprint. Empty lines and comment lines are skipped.
+func parseAuthorizedKeys(text string) (map[string]string, error) {
+	keys := map[string]string{}
+
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		_, comment, options, _, err := ssh.ParseAuthorizedKey([]byte(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		if len(options) > 0 {
+			return nil, fmt.Errorf("line %d: options are not supported", i+1)
+		}
+
+		publicKey, err := parseSSHPublicKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		name := comment
+		if name == "" {
+			name = ssh.FingerprintSHA256(publicKey)
+		}
+
+		if _, ok := keys[name]; ok {
+			return nil, fmt.Errorf("line %d: the name %q is used by multiple keys", i+1, name)
+		}
+
+		keys[name] = line
+	}
+
+	return keys, nil
+}
+
+// sshKeySetChanges are the API calls which reconcile the SSH keys with a name prefix.
+type sshKeySetChanges struct {
+	// Create maps the names of the SSH keys to create to their public keys.
+	Create map[string]string
+
+	// Delete are the ids of the SSH keys to delete.
+	Delete []string
+}
+
+// planSSHKeySet compares the existing SSH keys with the name prefix to the
+// desired keys, which are named without the prefix. Keys without the prefix are
+// left alone. Keys are immutable, so a changed key is deleted and created again.
+func planSSHKeySet(prefix string, desired map[string]string, existing []genesiscloud.SSHKey) sshKeySetChanges {
+	changes := sshKeySetChanges{
+		Create: map[string]string{},
+	}
+
+	kept := map[string]bool{}
+
+	for _, sshKey := range existing {
+		if !strings.HasPrefix(sshKey.Name, prefix) {
+			continue
+		}
+
+		publicKey, ok := desired[strings.TrimPrefix(sshKey.Name, prefix)]
+		if ok && !kept[sshKey.Name] && sshPublicKeysEqual(publicKey, sshKey.Value) {
+			kept[sshKey.Name] = true
+			continue
+		}
+
+		changes.Delete = append(changes.Delete, sshKey.Id)
+	}
+
+	for name, publicKey := range desired {
+		if !kept[prefix+name] {
+			changes.Create[prefix+name] = publicKey
+		}
+	}
+
+	sort.Strings(changes.Delete)
+
+	return changes
+}
+
+// sshKeyFingerprint returns the SHA256 fingerprint of the SSH key, computed
+// from its public key if possible.
+func sshKeyFingerprint(sshKey genesiscloud.SSHKey) string {
+	publicKey, err := parseSSHPublicKey(sshKey.Value)
+	if err != nil {
+		return sshKey.Fingerprint
+	}
+
+	return ssh.FingerprintSHA256(publicKey)
+}
+
+// runParallel runs the tasks with at most limit tasks at the same time and
+// returns the diagnostics of all tasks. Rate limit responses of the API are
+// retried by the client.
+func runParallel(limit int, tasks []func() diag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	semaphore := make(chan struct{}, limit)
+
+	for _, task := range tasks {
+		semaphore <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			taskDiags := task()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			diags.Append(taskDiags...)
+		}()
+	}
+
+	wg.Wait()
+
+	return diags
+}
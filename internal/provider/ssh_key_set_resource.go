@@ -0,0 +1,391 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var (
+	_ resource.Resource                = &SSHKeySetResource{}
+	_ resource.ResourceWithConfigure   = &SSHKeySetResource{}
+	_ resource.ResourceWithImportState = &SSHKeySetResource{}
+
+	_ resource.ResourceWithConfigValidators = &SSHKeySetResource{}
+	_ resource.ResourceWithModifyPlan       = &SSHKeySetResource{}
+)
+
+func NewSSHKeySetResource() resource.Resource {
+	return &SSHKeySetResource{}
+}
+
+// SSHKeySetResource defines the resource implementation.
+type SSHKeySetResource struct {
+	ResourceWithClient
+	ResourceWithTimeout
+}
+
+func (r *SSHKeySetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_key_set"
+}
+
+func (r *SSHKeySetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "SSH key set resource. Manages all SSH keys of the account whose name starts with the `name_prefix`: " +
+			"missing keys are created and keys which are not configured anymore are deleted. SSH keys with other names are left alone.",
+
+		Attributes: map[string]schema.Attribute{
+			"authorized_keys": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The SSH public keys in authorized_keys format, one key per line. " +
+					"The comment of a key is its name, keys without a comment are named by their fingerprint. " +
+					"Exactly one of `authorized_keys` or `keys` must be provided.",
+				Optional: true,
+				Validators: []validator.String{
+					authorizedKeysValidator{},
+				},
+			}),
+			"fingerprints": resourceenhancer.Attribute(ctx, schema.MapAttribute{
+				MarkdownDescription: "The SHA256 fingerprints of the SSH keys by name without the `name_prefix`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			}),
+			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The name prefix of the SSH keys.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), // immutable
+				},
+			}),
+			"keys": resourceenhancer.Attribute(ctx, schema.MapAttribute{
+				MarkdownDescription: "The SSH public keys by name without the `name_prefix`. " +
+					"Exactly one of `authorized_keys` or `keys` must be provided.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+					mapvalidator.ValueStringsAre(sshPublicKeyValidator{}),
+				},
+			}),
+			"name_prefix": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The prefix of the names of the managed SSH keys, e.g. `team-`. " +
+					"All SSH keys of the account with this prefix are managed by this resource. " +
+					"The prefixes of multiple SSH key sets must not overlap, e.g. sets with `team-` and `team-ops-` delete each other's keys.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			}),
+			"ssh_key_ids": resourceenhancer.Attribute(ctx, schema.MapAttribute{
+				MarkdownDescription: "The ids of the SSH keys by name without the `name_prefix`, e.g. for the `ssh_key_ids` of an instance.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			}),
+
+			// Internal
+			"timeouts": timeouts.AttributesAll(ctx),
+		},
+	}
+}
+
+func (r *SSHKeySetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("authorized_keys"),
+			path.MatchRoot("keys"),
+		),
+	}
+}
+
+func (r *SSHKeySetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to reconcile on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan SSHKeySetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || !plan.IsKnown() {
+		return
+	}
+
+	fingerprints, diags := plan.DesiredFingerprints(ctx)
+	if diags.HasError() {
+		// The validators report invalid keys
+		return
+	}
+
+	plan.Fingerprints = fingerprints
+
+	if !req.State.Raw.IsNull() {
+		var state SSHKeySetResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// Keep the ids if the keys are unchanged, otherwise they are only known after the reconciliation
+		if state.Fingerprints.Equal(fingerprints) {
+			plan.SshKeyIds = state.SshKeyIds
+		} else {
+			plan.SshKeyIds = types.MapUnknown(types.StringType)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *SSHKeySetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SSHKeySetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a ssh_key_set resource")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeySetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SSHKeySetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Read)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	sshKeys, diag := listSSHKeys(ctx, r.client)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, sshKeys)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a ssh_key_set resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeySetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SSHKeySetResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Update)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a ssh_key_set resource")
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SSHKeySetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SSHKeySetResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Delete)
+	if diag != nil {
+		resp.Diagnostics.Append(diag...)
+		return
+	}
+	defer cancel()
+
+	sshKeys, diag := listSSHKeys(ctx, r.client)
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changes := planSSHKeySet(data.NamePrefix.ValueString(), nil, sshKeys)
+
+	resp.Diagnostics.Append(r.deleteSSHKeys(ctx, changes.Delete)...)
+}
+
+func (r *SSHKeySetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name_prefix"), req.ID)...)
+}
+
+// reconcile creates the missing and deletes the removed SSH keys with the name prefix.
+func (r *SSHKeySetResource) reconcile(ctx context.Context, data *SSHKeySetResourceModel) (diags diag.Diagnostics) {
+	desired, diag := data.DesiredKeys(ctx)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return
+	}
+
+	sshKeys, diag := listSSHKeys(ctx, r.client)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return
+	}
+
+	changes := planSSHKeySet(data.NamePrefix.ValueString(), desired, sshKeys)
+
+	tflog.Debug(ctx, "reconciling ssh_key_set", map[string]interface{}{
+		"create": len(changes.Create),
+		"delete": len(changes.Delete),
+	})
+
+	// Delete first, so changed keys can be created again
+	diags.Append(r.deleteSSHKeys(ctx, changes.Delete)...)
+	if diags.HasError() {
+		return
+	}
+
+	created, diag := r.createSSHKeys(ctx, changes.Create)
+	diags.Append(diag...)
+	if diags.HasError() {
+		return
+	}
+
+	deleted := make(map[string]bool, len(changes.Delete))
+	for _, sshKeyId := range changes.Delete {
+		deleted[sshKeyId] = true
+	}
+
+	result := created
+	for _, sshKey := range sshKeys {
+		if !deleted[sshKey.Id] {
+			result = append(result, sshKey)
+		}
+	}
+
+	diags.Append(data.PopulateFromClientResponse(ctx, result)...)
+
+	return
+}
+
+// createSSHKeys creates the SSH keys in parallel and returns the created keys.
+func (r *SSHKeySetResource) createSSHKeys(ctx context.Context, publicKeys map[string]string) ([]genesiscloud.SSHKey, diag.Diagnostics) {
+	created := make([]genesiscloud.SSHKey, len(publicKeys))
+	tasks := make([]func() diag.Diagnostics, 0, len(publicKeys))
+
+	for name, publicKey := range publicKeys {
+		i := len(tasks)
+
+		tasks = append(tasks, func() (diags diag.Diagnostics) {
+			response, err := r.client.CreateSSHKeyWithResponse(ctx, genesiscloud.CreateSSHKeyJSONRequestBody{
+				Name:  name,
+				Value: publicKey,
+			})
+			if err != nil {
+				diags.AddError("Client Error", generateErrorMessage(fmt.Sprintf("create ssh_key %q", name), err))
+				return
+			}
+
+			sshkeyResponse := response.JSON201
+			if sshkeyResponse == nil {
+				diags.AddError("Client Error", generateClientErrorMessage(fmt.Sprintf("create ssh_key %q", name), ErrorResponse{
+					Body:         response.Body,
+					HTTPResponse: response.HTTPResponse,
+					Error:        response.JSONDefault,
+				}))
+				return
+			}
+
+			created[i] = *sshkeyResponse
+
+			return
+		})
+	}
+
+	diags := runParallel(sshKeySetParallelism, tasks)
+
+	return created, diags
+}
+
+// deleteSSHKeys deletes the SSH keys in parallel. Keys which are already deleted are skipped.
+func (r *SSHKeySetResource) deleteSSHKeys(ctx context.Context, sshKeyIds []string) diag.Diagnostics {
+	tasks := make([]func() diag.Diagnostics, 0, len(sshKeyIds))
+
+	for _, sshKeyId := range sshKeyIds {
+		tasks = append(tasks, func() (diags diag.Diagnostics) {
+			response, err := r.client.DeleteSSHKeyWithResponse(ctx, sshKeyId)
+			if err != nil {
+				diags.AddError("Client Error", generateErrorMessage("delete ssh_key", err))
+				return
+			}
+
+			if response.StatusCode() != 204 && response.StatusCode() != 404 {
+				diags.AddError("Client Error", generateClientErrorMessage("delete ssh_key", ErrorResponse{
+					Body:         response.Body,
+					HTTPResponse: response.HTTPResponse,
+					Error:        response.JSONDefault,
+				}))
+				return
+			}
+
+			return
+		})
+	}
+
+	return runParallel(sshKeySetParallelism, tasks)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSSHKeySetResourceConfig(namePrefix, authorizedKeys string) string {
+	return fmt.Sprintf(`
+resource "genesiscloud_ssh_key_set" "test" {
+  name_prefix     = %[1]q
+  authorized_keys = %[2]q
+}
+`, namePrefix, authorizedKeys)
+}
+
+func TestAccSSHKeySetResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + testAccSSHKeySetResourceConfig("acc-test-", samplePublicKey+"\n"+otherSamplePublicKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_ssh_key_set.test", "ssh_key_ids.%", "2"),
+					resource.TestCheckResourceAttrSet("genesiscloud_ssh_key_set.test", "ssh_key_ids.alice@example.com"),
+					resource.TestCheckResourceAttrSet("genesiscloud_ssh_key_set.test", "ssh_key_ids.bob@example.com"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "genesiscloud_ssh_key_set.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"authorized_keys"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + testAccSSHKeySetResourceConfig("acc-test-", samplePublicKey),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("genesiscloud_ssh_key_set.test", "ssh_key_ids.%", "1"),
+					resource.TestCheckResourceAttrSet("genesiscloud_ssh_key_set.test", "ssh_key_ids.alice@example.com"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestParseAuthorizedKeys(t *testing.T) {
+	withoutComment := strings.TrimSuffix(otherSamplePublicKey, " bob@example.com")
+
+	keys, err := parseAuthorizedKeys("# team\n" + samplePublicKey + "\n\n  " + withoutComment + "  \n")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	fingerprint := sshKeyFingerprint(genesiscloud.SSHKey{Value: withoutComment})
+	if !strings.HasPrefix(fingerprint, "SHA256:") {
+		t.Fatalf("expected a SHA256 fingerprint, got %s", fingerprint)
+	}
+
+	expected := map[string]string{
+		"alice@example.com": samplePublicKey,
+		fingerprint:         withoutComment,
+	}
+
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected %v, got %v", expected, keys)
+	}
+
+	invalid := map[string]string{
+		"invalid key":    "ssh-ed25519 invalid",
+		"options":        `command="true" ` + samplePublicKey,
+		"duplicate name": samplePublicKey + "\n" + strings.Replace(otherSamplePublicKey, "bob@", "alice@", 1),
+		"weak key":       testRSAPublicKey(t, 1024),
+	}
+
+	for name, text := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseAuthorizedKeys(text); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestPlanSSHKeySet(t *testing.T) {
+	existing := []genesiscloud.SSHKey{
+		{Id: "1", Name: "team-alice", Value: samplePublicKey},
+		{Id: "2", Name: "team-bob", Value: samplePublicKey},
+		{Id: "3", Name: "team-carol", Value: otherSamplePublicKey},
+		{Id: "4", Name: "other", Value: samplePublicKey},
+		{Id: "5", Name: "team-alice", Value: samplePublicKey},
+	}
+
+	desired := map[string]string{
+		// unchanged except for the comment
+		"alice": strings.Replace(samplePublicKey, "alice@", "alice@laptop.", 1),
+		// changed key
+		"bob": otherSamplePublicKey,
+		// new key
+		"dave": otherSamplePublicKey,
+	}
+
+	changes := planSSHKeySet("team-", desired, existing)
+
+	expected := sshKeySetChanges{
+		Create: map[string]string{
+			"team-bob":  otherSamplePublicKey,
+			"team-dave": otherSamplePublicKey,
+		},
+		// bob changed, carol removed and the duplicate of alice
+		Delete: []string{"2", "3", "5"},
+	}
+
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+
+	changes = planSSHKeySet("team-", nil, existing)
+	if len(changes.Create) != 0 || !reflect.DeepEqual(changes.Delete, []string{"1", "2", "3", "5"}) {
+		t.Errorf("expected all keys with the prefix to be deleted, got %v", changes)
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	var running, maxRunning atomic.Int32
+
+	tasks := make([]func() diag.Diagnostics, 0, 10)
+	for i := 0; i < 10; i++ {
+		tasks = append(tasks, func() (diags diag.Diagnostics) {
+			current := running.Add(1)
+			defer running.Add(-1)
+
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			time.Sleep(10 * time.Millisecond)
+
+			if i%5 == 0 {
+				diags.AddError("Task Error", fmt.Sprintf("task %d failed", i))
+			}
+
+			return
+		})
+	}
+
+	diags := runParallel(3, tasks)
+
+	if maxRunning.Load() > 3 {
+		t.Errorf("expected at most 3 running tasks, got %d", maxRunning.Load())
+	}
+
+	if diags.ErrorsCount() != 2 {
+		t.Errorf("expected 2 errors, got %d", diags.ErrorsCount())
+	}
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type SSHKeySetResourceModel struct {
+	// AuthorizedKeys The SSH keys in authorized_keys format.
+	AuthorizedKeys types.String `tfsdk:"authorized_keys"`
+
+	// Fingerprints The fingerprints of the SSH keys by name.
+	Fingerprints types.Map `tfsdk:"fingerprints"`
+
+	// Id The name prefix of the SSH keys.
+	Id types.String `tfsdk:"id"`
+
+	// Keys The public keys by name.
+	Keys types.Map `tfsdk:"keys"`
+
+	// NamePrefix The prefix of the names of the SSH keys.
+	NamePrefix types.String `tfsdk:"name_prefix"`
+
+	// SshKeyIds The ids of the SSH keys by name.
+	SshKeyIds types.Map `tfsdk:"ssh_key_ids"`
+
+	// Internal
+
+	// Timeouts The resource timeouts
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// IsKnown reports whether the configured public keys are known.
+func (data *SSHKeySetResourceModel) IsKnown() bool {
+	if data.AuthorizedKeys.IsUnknown() || data.Keys.IsUnknown() {
+		return false
+	}
+
+	for _, element := range data.Keys.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DesiredKeys returns the configured public keys by name without the name prefix.
+func (data *SSHKeySetResourceModel) DesiredKeys(ctx context.Context) (keys map[string]string, diags diag.Diagnostics) {
+	if !data.AuthorizedKeys.IsNull() {
+		keys, err := parseAuthorizedKeys(data.AuthorizedKeys.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("authorized_keys"), "Invalid Authorized Keys", err.Error())
+		}
+
+		return keys, diags
+	}
+
+	keys = map[string]string{}
+	diags.Append(data.Keys.ElementsAs(ctx, &keys, false)...)
+
+	return keys, diags
+}
+
+// DesiredFingerprints returns the fingerprints of the configured public keys by name.
+func (data *SSHKeySetResourceModel) DesiredFingerprints(ctx context.Context) (fingerprints types.Map, diags diag.Diagnostics) {
+	keys, diags := data.DesiredKeys(ctx)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	result := make(map[string]string, len(keys))
+	for name, publicKey := range keys {
+		result[name] = sshKeyFingerprint(genesiscloud.SSHKey{Value: publicKey})
+	}
+
+	fingerprints, diags = types.MapValueFrom(ctx, types.StringType, result)
+
+	return fingerprints, diags
+}
+
+func (data *SSHKeySetResourceModel) PopulateFromClientResponse(ctx context.Context, sshKeys []genesiscloud.SSHKey) (diags diag.Diagnostics) {
+	prefix := data.NamePrefix.ValueString()
+
+	fingerprints := map[string]string{}
+	sshKeyIds := map[string]string{}
+
+	for _, sshKey := range sshKeys {
+		if !strings.HasPrefix(sshKey.Name, prefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(sshKey.Name, prefix)
+		fingerprints[name] = sshKeyFingerprint(sshKey)
+		sshKeyIds[name] = sshKey.Id
+	}
+
+	data.Id = types.StringValue(prefix)
+
+	var diag diag.Diagnostics
+
+	data.Fingerprints, diag = types.MapValueFrom(ctx, types.StringType, fingerprints)
+	diags.Append(diag...)
+
+	data.SshKeyIds, diag = types.MapValueFrom(ctx, types.StringType, sshKeyIds)
+	diags.Append(diag...)
+
+	return
+}
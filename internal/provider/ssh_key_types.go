@@ -10,20 +10,58 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+type SSHKeyGenerateModel struct {
+	// Algorithm The algorithm of the generated key pair.
+	Algorithm types.String `tfsdk:"algorithm"`
+
+	// RsaBits The size of a generated RSA key.
+	RsaBits types.Int64 `tfsdk:"rsa_bits"`
+}
+
+// AlgorithmValue returns the configured algorithm or the default algorithm.
+func (data *SSHKeyGenerateModel) AlgorithmValue() string {
+	if data.Algorithm.IsNull() || data.Algorithm.IsUnknown() {
+		return SSHKeyAlgorithmED25519
+	}
+
+	return data.Algorithm.ValueString()
+}
+
+// RsaBitsValue returns the configured RSA key size or the default size.
+func (data *SSHKeyGenerateModel) RsaBitsValue() int {
+	if data.RsaBits.IsNull() || data.RsaBits.IsUnknown() {
+		return defaultSSHKeyRSABits
+	}
+
+	return int(data.RsaBits.ValueInt64())
+}
+
 type SSHKeyResourceModel struct {
 	CreatedAt types.String `tfsdk:"created_at"`
 
 	// Fingerprint The fingerprint of the SSH key.
 	Fingerprint types.String `tfsdk:"fingerprint"`
 
+	// Generate Option to generate the key pair in the provider.
+	Generate *SSHKeyGenerateModel `tfsdk:"generate"`
+
 	// Id The unique ID of the SSH key.
 	Id types.String `tfsdk:"id"`
 
 	// Name The human-readable name for the SSH key.
 	Name types.String `tfsdk:"name"`
 
+	// PrivateKeyOpenssh The generated private key in OpenSSH format.
+	PrivateKeyOpenssh types.String `tfsdk:"private_key_openssh"`
+
 	// PublicKey SSH public key.
-	PublicKey types.String `tfsdk:"public_key"`
+	PublicKey SSHPublicKeyValue `tfsdk:"public_key"`
+
+	// PublicKeyWo SSH public key which is not stored in the plan.
+	PublicKeyWo types.String `tfsdk:"public_key_wo"`
+
+	// PublicKeyWoVersion The version of the write-only public key.
+	PublicKeyWoVersion types.Int64 `tfsdk:"public_key_wo_version"`
 
 	// Internal
 
@@ -33,10 +71,27 @@ type SSHKeyResourceModel struct {
 
 func (data *SSHKeyResourceModel) PopulateFromClientResponse(ctx context.Context, sshKey *genesiscloud.SSHKey) (diag diag.Diagnostics) {
 	data.CreatedAt = types.StringValue(sshKey.CreatedAt.Format(time.RFC3339))
-	data.Fingerprint = types.StringValue(sshKey.Fingerprint)
+	// The fingerprint is normalized to the SHA256 fingerprint, which is planned for new keys
+	data.Fingerprint = types.StringValue(sshKeyFingerprint(*sshKey))
 	data.Id = types.StringValue(sshKey.Id)
 	data.Name = types.StringValue(sshKey.Name)
-	data.PublicKey = types.StringValue(sshKey.Value)
+
+	// The configured whitespace and comment are kept by the semantic equality of the value
+	data.PublicKey = NewSSHPublicKeyValue(sshKey.Value)
 
 	return
 }
+
+type SSHKeyPairEphemeralResourceModel struct {
+	// Algorithm The algorithm of the key pair.
+	Algorithm types.String `tfsdk:"algorithm"`
+
+	// PrivateKeyOpenssh The private key in OpenSSH format.
+	PrivateKeyOpenssh types.String `tfsdk:"private_key_openssh"`
+
+	// PublicKeyOpenssh The public key in authorized_keys format.
+	PublicKeyOpenssh types.String `tfsdk:"public_key_openssh"`
+
+	// RsaBits The size of an RSA key.
+	RsaBits types.Int64 `tfsdk:"rsa_bits"`
+}
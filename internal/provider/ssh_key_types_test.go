@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHKeyResourceModelPopulateFromClientResponseFingerprint(t *testing.T) {
+	publicKey, err := parseSSHPublicKey(otherSamplePublicKey)
+	if err != nil {
+		t.Fatalf("parsing key: %s", err)
+	}
+
+	testCases := map[string]struct {
+		value       string
+		fingerprint string
+		expected    string
+	}{
+		"md5 fingerprint": {
+			value:       otherSamplePublicKey,
+			fingerprint: ssh.FingerprintLegacyMD5(publicKey),
+			expected:    ssh.FingerprintSHA256(publicKey),
+		},
+		"sha256 fingerprint": {
+			value:       otherSamplePublicKey,
+			fingerprint: ssh.FingerprintSHA256(publicKey),
+			expected:    ssh.FingerprintSHA256(publicKey),
+		},
+		"invalid key": {
+			value:       "ssh-ed25519 invalid",
+			fingerprint: "aa:bb",
+			expected:    "aa:bb",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var data SSHKeyResourceModel
+
+			diags := data.PopulateFromClientResponse(context.Background(), &genesiscloud.SSHKey{
+				CreatedAt:   time.Now(),
+				Fingerprint: testCase.fingerprint,
+				Id:          "key-1",
+				Name:        "bob",
+				Value:       testCase.value,
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if actual := data.Fingerprint.ValueString(); actual != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSSHKeyResourceImportStateFingerprint(t *testing.T) {
+	ctx := context.Background()
+
+	publicKey, err := parseSSHPublicKey(otherSamplePublicKey)
+	if err != nil {
+		t.Fatalf("parsing key: %s", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/ssh-keys" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ssh_keys": []map[string]interface{}{
+				{"id": "key-1", "name": "bob", "value": otherSamplePublicKey, "fingerprint": ssh.FingerprintLegacyMD5(publicKey), "created_at": time.Now()},
+				{"id": "key-2", "name": "alice", "value": "ssh-ed25519 invalid", "fingerprint": "aa:bb", "created_at": time.Now()},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ctx, ClientConfig{
+		ClientConfig: genesiscloud.ClientConfig{
+			Endpoint: server.URL,
+			Token:    "fake-token",
+		},
+	})
+	if err != nil {
+		t.Fatalf("creating client: %s", err)
+	}
+
+	r := &SSHKeyResource{}
+	r.client = client
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	testCases := map[string]struct {
+		importId string
+		id       string
+	}{
+		"sha256 fingerprint": {importId: "fingerprint:" + ssh.FingerprintSHA256(publicKey), id: "key-1"},
+		"md5 fingerprint":    {importId: "fingerprint:" + ssh.FingerprintLegacyMD5(publicKey), id: "key-1"},
+		"api fingerprint":    {importId: "fingerprint:aa:bb", id: "key-2"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, resource.ImportStateRequest{ID: testCase.importId}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != testCase.id {
+				t.Errorf("expected %q, got %q", testCase.id, id.ValueString())
+			}
+		})
+	}
+}
@@ -35,3 +35,11 @@ func (d *DataSourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutF
 func (r *ResourceWithTimeout) ContextWithTimeout(ctx context.Context, timeoutFn CreateFn) (context.Context, context.CancelFunc, diag.Diagnostics) {
 	return contextWithTimeout(ctx, timeoutFn)
 }
+
+// withDefaultTimeout replaces the default timeout of timeoutFn, e.g. for
+// operations which take considerably longer than usual.
+func withDefaultTimeout(timeoutFn CreateFn, timeout time.Duration) CreateFn {
+	return func(ctx context.Context, _ time.Duration) (time.Duration, diag.Diagnostics) {
+		return timeoutFn(ctx, timeout)
+	}
+}
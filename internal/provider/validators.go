@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = timezoneValidator{}
+
+// timezoneValidator validates that a string is an IANA time zone name.
+type timezoneValidator struct{}
+
+func (v timezoneValidator) Description(ctx context.Context) string {
+	return "value must be an IANA time zone name, e.g. `Europe/Berlin`"
+}
+
+func (v timezoneValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timezoneValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := time.LoadLocation(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time Zone",
+			fmt.Sprintf("The value %q is not a valid time zone: %s.", req.ConfigValue.ValueString(), err))
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a positive duration, e.g. `30s` or `5m`.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, e.g. `30s` or `5m`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("the duration must be positive")
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration",
+			fmt.Sprintf("The value %q is not a valid duration: %s.", req.ConfigValue.ValueString(), err))
+	}
+}
+
+var _ validator.String = sshPublicKeyValidator{}
+
+// sshPublicKeyValidator validates that a string is an SSH public key which is not considered weak.
+type sshPublicKeyValidator struct{}
+
+func (v sshPublicKeyValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be an SSH public key in authorized_keys format, RSA keys must have at least %d bits", sshKeyMinRSABits)
+}
+
+func (v sshPublicKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v sshPublicKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := parseSSHPublicKey(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid SSH Public Key",
+			fmt.Sprintf("The value is not a valid SSH public key: %s.", err))
+	}
+}
+
+var _ validator.String = authorizedKeysValidator{}
+
+// authorizedKeysValidator validates that a string contains SSH public keys in authorized_keys format with unique names.
+type authorizedKeysValidator struct{}
+
+func (v authorizedKeysValidator) Description(ctx context.Context) string {
+	return "value must contain SSH public keys in authorized_keys format without options and with unique comments"
+}
+
+func (v authorizedKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v authorizedKeysValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := parseAuthorizedKeys(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Authorized Keys",
+			fmt.Sprintf("The value is not valid: %s.", err))
+	}
+}
+
+var _ validator.String = regexValidator{}
+
+// regexValidator validates that a string is a valid regular expression.
+type regexValidator struct{}
+
+func (v regexValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression in RE2 syntax"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := regexp.Compile(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Regular Expression",
+			fmt.Sprintf("The value %q is not a valid regular expression: %s.", req.ConfigValue.ValueString(), err))
+	}
+}
+
+var _ validator.String = rfc3339Validator{}
+
+// rfc3339Validator validates that a string is a timestamp in RFC 3339.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be a timestamp in RFC 3339, e.g. `2024-01-31T12:00:00Z`"
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	_, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Timestamp",
+			fmt.Sprintf("The value %q is not a valid RFC 3339 timestamp: %s.", req.ConfigValue.ValueString(), err))
+	}
+}
+
+var _ validator.String = mountPointValidator{}
+
+// mountPointValidator validates that a string is a mount point which can be used unquoted.
+type mountPointValidator struct{}
+
+func (v mountPointValidator) Description(ctx context.Context) string {
+	return "value must be an absolute path of letters, digits, `.`, `_`, `-` and `/`, e.g. `/mnt/data`"
+}
+
+func (v mountPointValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v mountPointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !filesystemMountPointRegex.MatchString(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Mount Point",
+			fmt.Sprintf("The value %q is not an absolute path of letters, digits, `.`, `_`, `-` and `/`.", req.ConfigValue.ValueString()))
+	}
+}
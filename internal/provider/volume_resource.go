@@ -9,6 +9,7 @@ import (
 	"github.com/genesiscloud/terraform-provider-genesiscloud/internal/resourceenhancer"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -24,8 +25,20 @@ var (
 	_ resource.Resource                = &VolumeResource{}
 	_ resource.ResourceWithConfigure   = &VolumeResource{}
 	_ resource.ResourceWithImportState = &VolumeResource{}
+	_ resource.ResourceWithModifyPlan  = &VolumeResource{}
+
+	_ resource.ResourceWithConfigValidators = &VolumeResource{}
 )
 
+// volumeReplaceAttributes are the attributes whose change replaces the volume,
+// which the deletion protection prevents. They have to match the schema.
+var volumeReplaceAttributes = []path.Path{
+	path.Root("region"),
+	path.Root("source_snapshot_id"),
+	path.Root("source_volume_id"),
+	path.Root("type"),
+}
+
 func NewVolumeResource() resource.Resource {
 	return &VolumeResource{}
 }
@@ -61,6 +74,7 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					defaultplanmodifier.String(""),
 				},
 			}),
+			"deletion_protection": deletionProtectionAttribute(ctx, "volume"),
 			"id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the volume.",
 				Computed:            true,
@@ -82,13 +96,40 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringvalidator.OneOf(sliceStringify(genesiscloud.AllRegions)...),
 				},
 			}),
+			"replace_on_shrink": resourceenhancer.Attribute(ctx, schema.BoolAttribute{
+				MarkdownDescription: "Flag to replace the volume if its `size` is decreased, which deletes all of its data. Otherwise decreasing the size is an error.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					defaultplanmodifier.Bool(false),
+				},
+			}),
 			"size": resourceenhancer.Attribute(ctx, schema.Int64Attribute{
 				MarkdownDescription: "The storage size of this volume given in GiB.",
 				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					sizePlanModifier{resourceName: "volume"},
+				},
 				Validators: []validator.Int64{
 					int64validator.AtLeast(1),
 				},
 			}),
+			"source_snapshot_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the snapshot to create the volume from. The `size` has to be at least the size of the snapshot. " +
+					"The volume is only created once the data is copied, so the default `create` timeout is 60 minutes.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					volumeSourceRequiresReplace{},
+				},
+			}),
+			"source_volume_id": resourceenhancer.Attribute(ctx, schema.StringAttribute{
+				MarkdownDescription: "The id of the volume to clone. The `size` has to be at least the size of the source volume. " +
+					"The volume is only created once the data is copied, so the default `create` timeout is 60 minutes.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					volumeSourceRequiresReplace{},
+				},
+			}),
 			"status": resourceenhancer.Attribute(ctx, schema.StringAttribute{
 				MarkdownDescription: "The volume status.",
 				Computed:            true,
@@ -122,6 +163,48 @@ func (r *VolumeResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *VolumeResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("source_snapshot_id"),
+			path.MatchRoot("source_volume_id"),
+		),
+	}
+}
+
+func (r *VolumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to protect on create, only the size is validated against the source
+	if req.State.Raw.IsNull() {
+		// The source cannot be read if the provider is not configured yet
+		if r.client == nil {
+			return
+		}
+
+		var plan VolumeResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		if resp.Diagnostics.HasError() || plan.Size.IsUnknown() {
+			return
+		}
+
+		source, sourceSize, diags := volumeSource(ctx, r.client, &plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(validateVolumeSourceSize(plan.Size.ValueInt64(), source, sourceSize)...)
+		return
+	}
+
+	attributes, diags := volumeProtectedAttributes(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(deletionProtectionResizablePlan(ctx, "volume", req, attributes)...)
+}
+
 func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data VolumeResourceModel
 
@@ -131,13 +214,30 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	ctx, cancel, diag := r.ContextWithTimeout(ctx, data.Timeouts.Create)
+	createTimeout := data.Timeouts.Create
+	if !data.SourceSnapshotId.IsNull() || !data.SourceVolumeId.IsNull() {
+		createTimeout = withDefaultTimeout(createTimeout, defaultVolumeHydrationTimeout)
+	}
+
+	ctx, cancel, diag := r.ContextWithTimeout(ctx, createTimeout)
 	if diag != nil {
 		resp.Diagnostics.Append(diag...)
 		return
 	}
 	defer cancel()
 
+	// The size is validated on plan too, unless the source was not known yet
+	source, sourceSize, diags := volumeSource(ctx, r.client, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateVolumeSourceSize(data.Size.ValueInt64(), source, sourceSize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	body := genesiscloud.CreateVolumeJSONRequestBody{}
 
 	body.Description = pointer(data.Description.ValueString())
@@ -146,6 +246,13 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 	body.Size = int(data.Size.ValueInt64())
 	body.Type = pointer(genesiscloud.VolumeType(data.Type.ValueString()))
 
+	if !data.SourceSnapshotId.IsNull() {
+		body.SourceSnapshotId = pointer(data.SourceSnapshotId.ValueString())
+	}
+	if !data.SourceVolumeId.IsNull() {
+		body.SourceVolumeId = pointer(data.SourceVolumeId.ValueString())
+	}
+
 	response, err := r.client.CreateVolumeWithResponse(ctx, body)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", generateErrorMessage("create volume", err))
@@ -177,49 +284,28 @@ func (r *VolumeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	volumeId := volumeResponse.Volume.Id
 
-	for {
-		err := r.client.PollingWait(ctx)
-		if err != nil {
-			resp.Diagnostics.AddError("Polling Error", generateErrorMessage("polling volume", err))
-			return
-		}
-
-		tflog.Trace(ctx, "polling a volume resource")
+	// Volumes created from a source stay in the intermediate statuses until the data is copied
+	volume, diag := waitForVolume(ctx, r.client, volumeId, func(volume *genesiscloud.Volume) bool {
+		return volume.Status == genesiscloud.VolumeStatusCreated || volume.Status == genesiscloud.VolumeStatusError
+	})
+	resp.Diagnostics.Append(diag...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		response, err := r.client.GetVolumeWithResponse(ctx, volumeId)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", generateErrorMessage("polling volume", err))
-			return
-		}
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, volume)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		volumeResponse := response.JSON200
-		if volumeResponse == nil {
-			resp.Diagnostics.AddError("Client Error", generateClientErrorMessage("polling volume", ErrorResponse{
-				Body:         response.Body,
-				HTTPResponse: response.HTTPResponse,
-				Error:        response.JSONDefault,
-			}))
-			return
-		}
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		status := volumeResponse.Volume.Status
-		if status == genesiscloud.VolumeStatusCreated || status == genesiscloud.VolumeStatusError {
-			resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &volumeResponse.Volume)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			// Save data into Terraform state
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			if status == genesiscloud.VolumeStatusError {
-				resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling volume", ErrResourceInErrorState))
-			}
-			return
-		}
+	if volume.Status == genesiscloud.VolumeStatusError {
+		resp.Diagnostics.AddError("Provisioning Error", generateErrorMessage("polling volume", ErrResourceInErrorState))
 	}
 }
 
@@ -270,9 +356,11 @@ func (r *VolumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data VolumeResourceModel
+	var state VolumeResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -308,13 +396,36 @@ func (r *VolumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, &volumeResponse.Volume)...)
+	volume := &volumeResponse.Volume
+
+	if size := data.Size.ValueInt64(); size > state.Size.ValueInt64() {
+		tflog.Info(ctx, "waiting for the online resize of a volume", map[string]interface{}{"id": volumeId, "size": size})
+
+		// The volume is resized online, the new size shows up once the resize is done
+		volume, diag = waitForVolume(ctx, r.client, volumeId, func(volume *genesiscloud.Volume) bool {
+			return int64(volume.Size) >= size || volume.Status == genesiscloud.VolumeStatusError
+		})
+		resp.Diagnostics.Append(diag...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if volume.Status == genesiscloud.VolumeStatusError {
+			resp.Diagnostics.AddError("Resize Error", fmt.Sprintf("The volume with id %q failed to resize to %d GiB.", volumeId, size))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(data.PopulateFromClientResponse(ctx, volume)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	tflog.Trace(ctx, "updated a volume resource")
 
+	// The configured source of an imported volume is adopted now
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, volumeImportedKey, nil)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -337,6 +448,11 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 	volumeId := data.Id.ValueString()
 
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError("Deletion Protection", deletionProtectionError("volume", volumeId))
+		return
+	}
+
 	if data.RetainOnDelete.ValueBool() {
 		resp.Diagnostics.AddWarning(
 			"Volume is retained",
@@ -383,4 +499,7 @@ func (r *VolumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 func (r *VolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	// The source is not returned by the API
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, volumeImportedKey, []byte("true"))...)
 }
@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/genesiscloud/genesiscloud-go"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultVolumeHydrationTimeout is the default create timeout of volumes created
+// from a snapshot or another volume, as the data has to be copied first.
+const defaultVolumeHydrationTimeout = 60 * time.Minute
+
+func getVolume(ctx context.Context, client *Client, volumeId string, verb string) (*genesiscloud.Volume, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := client.GetVolumeWithResponse(ctx, volumeId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return nil, diags
+	}
+
+	volumeResponse := response.JSON200
+	if volumeResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &volumeResponse.Volume, diags
+}
+
+func getSnapshot(ctx context.Context, client *Client, snapshotId string, verb string) (*genesiscloud.Snapshot, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	response, err := client.GetSnapshotWithResponse(ctx, snapshotId)
+	if err != nil {
+		diags.AddError("Client Error", generateErrorMessage(verb, err))
+		return nil, diags
+	}
+
+	snapshotResponse := response.JSON200
+	if snapshotResponse == nil {
+		diags.AddError("Client Error", generateClientErrorMessage(verb, ErrorResponse{
+			Body:         response.Body,
+			HTTPResponse: response.HTTPResponse,
+			Error:        response.JSONDefault,
+		}))
+		return nil, diags
+	}
+
+	return &snapshotResponse.Snapshot, diags
+}
+
+// waitForVolume polls the volume until done returns true. Status changes, e.g.
+// while a volume is hydrated from its source, are logged.
+func waitForVolume(ctx context.Context, client *Client, volumeId string, done func(volume *genesiscloud.Volume) bool) (*genesiscloud.Volume, diag.Diagnostics) {
+	var status genesiscloud.VolumeStatus
+
+	for {
+		err := client.PollingWait(ctx)
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Polling Error", generateErrorMessage("polling volume", err))
+			return nil, diags
+		}
+
+		tflog.Trace(ctx, "polling a volume resource")
+
+		volume, diags := getVolume(ctx, client, volumeId, "polling volume")
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if volume.Status != status {
+			tflog.Debug(ctx, "volume status changed", map[string]interface{}{"id": volumeId, "status": string(volume.Status)})
+			status = volume.Status
+		}
+
+		if done(volume) {
+			return volume, diags
+		}
+	}
+}
+
+// volumeSource returns the attribute and size in GiB of the snapshot or volume the volume is created from.
+func volumeSource(ctx context.Context, client *Client, data *VolumeResourceModel) (path.Path, int64, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.SourceSnapshotId.IsNull() && !data.SourceSnapshotId.IsUnknown():
+		snapshot, diags := getSnapshot(ctx, client, data.SourceSnapshotId.ValueString(), "read source snapshot")
+		if diags.HasError() {
+			return path.Empty(), 0, diags
+		}
+
+		return path.Root("source_snapshot_id"), int64(snapshot.Size), diags
+
+	case !data.SourceVolumeId.IsNull() && !data.SourceVolumeId.IsUnknown():
+		volume, diags := getVolume(ctx, client, data.SourceVolumeId.ValueString(), "read source volume")
+		if diags.HasError() {
+			return path.Empty(), 0, diags
+		}
+
+		return path.Root("source_volume_id"), int64(volume.Size), diags
+	}
+
+	return path.Empty(), 0, diags
+}
+
+// validateVolumeSourceSize validates that the volume is at least as large as its source.
+func validateVolumeSourceSize(size int64, source path.Path, sourceSize int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if size < sourceSize {
+		diags.AddAttributeError(path.Root("size"), "Invalid Volume Size",
+			fmt.Sprintf("The volume has to be at least as large as its source (%s) with %d GiB, but the size is %d GiB.", source, sourceSize, size))
+	}
+
+	return diags
+}
+
+var _ planmodifier.String = volumeSourceRequiresReplace{}
+
+// volumeSourceRequiresReplace requires the replacement of the volume if its
+// source changes. The API does not return the source, so an imported volume
+// adopts the configured source without a replacement.
+type volumeSourceRequiresReplace struct{}
+
+func (m volumeSourceRequiresReplace) Description(ctx context.Context) string {
+	return "If the value of this attribute changes, the resource will be replaced. " +
+		"After an import, the configured value is adopted without a replacement, as the API does not return it."
+}
+
+func (m volumeSourceRequiresReplace) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m volumeSourceRequiresReplace) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace on create or destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	imported, diags := req.Private.GetKey(ctx, volumeImportedKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.RequiresReplace = volumeSourceChanged(imported != nil, req.PlanValue, req.StateValue)
+}
+
+// volumeProtectedAttributes returns the attributes whose change replaces the
+// volume. The sources without a prior value are left out for an imported
+// volume, which adopts them, see volumeSourceRequiresReplace.
+func volumeProtectedAttributes(ctx context.Context, req resource.ModifyPlanRequest) ([]path.Path, diag.Diagnostics) {
+	imported, diags := req.Private.GetKey(ctx, volumeImportedKey)
+	if diags.HasError() || imported == nil {
+		return volumeReplaceAttributes, diags
+	}
+
+	var attributes []path.Path
+
+	for _, attributePath := range volumeReplaceAttributes {
+		var stateValue attr.Value
+		diags.Append(req.State.GetAttribute(ctx, attributePath, &stateValue)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		if !stateValue.IsNull() {
+			attributes = append(attributes, attributePath)
+		}
+	}
+
+	return attributes, diags
+}
+
+// volumeSourceChanged reports whether the planned source of the volume differs
+// from the prior state. The source of an imported volume is unknown to the
+// prior state, so any configured source is adopted.
+func volumeSourceChanged(imported bool, planValue, stateValue types.String) bool {
+	if imported && stateValue.IsNull() {
+		return false
+	}
+
+	return !planValue.Equal(stateValue)
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateVolumeSourceSize(t *testing.T) {
+	testCases := map[string]struct {
+		size       int64
+		sourceSize int64
+		valid      bool
+	}{
+		"larger":  {size: 100, sourceSize: 50, valid: true},
+		"equal":   {size: 50, sourceSize: 50, valid: true},
+		"smaller": {size: 49, sourceSize: 50, valid: false},
+		// no source
+		"empty": {size: 1, sourceSize: 0, valid: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			diags := validateVolumeSourceSize(testCase.size, path.Root("source_snapshot_id"), testCase.sourceSize)
+			if diags.HasError() == testCase.valid {
+				t.Errorf("expected valid %t, got %v", testCase.valid, diags)
+			}
+		})
+	}
+}
+
+func TestVolumeSourceChanged(t *testing.T) {
+	testCases := map[string]struct {
+		imported   bool
+		planValue  types.String
+		stateValue types.String
+		changed    bool
+	}{
+		"unchanged":          {planValue: types.StringValue("a"), stateValue: types.StringValue("a"), changed: false},
+		"changed":            {planValue: types.StringValue("b"), stateValue: types.StringValue("a"), changed: true},
+		"added":              {planValue: types.StringValue("a"), stateValue: types.StringNull(), changed: true},
+		"removed":            {planValue: types.StringNull(), stateValue: types.StringValue("a"), changed: true},
+		"imported":           {imported: true, planValue: types.StringValue("a"), stateValue: types.StringNull(), changed: false},
+		"imported unchanged": {imported: true, planValue: types.StringNull(), stateValue: types.StringNull(), changed: false},
+		"imported adopted":   {imported: true, planValue: types.StringValue("b"), stateValue: types.StringValue("a"), changed: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if changed := volumeSourceChanged(testCase.imported, testCase.planValue, testCase.stateValue); changed != testCase.changed {
+				t.Errorf("expected changed %t, got %t", testCase.changed, changed)
+			}
+		})
+	}
+}
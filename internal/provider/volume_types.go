@@ -10,12 +10,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// volumeImportedKey is the private state key which marks an imported volume,
+// whose source is not returned by the API and is adopted from the configuration.
+const volumeImportedKey = "imported"
+
 type VolumeResourceModel struct {
 	CreatedAt types.String `tfsdk:"created_at"`
 
 	// Description The human-readable description for the volume.
 	Description types.String `tfsdk:"description"`
 
+	// DeletionProtection Flag to protect the volume from being destroyed or replaced.
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+
 	// Id The unique ID of the volume.
 	Id types.String `tfsdk:"id"`
 
@@ -25,9 +32,18 @@ type VolumeResourceModel struct {
 	// Region The region identifier.
 	Region types.String `tfsdk:"region"`
 
+	// ReplaceOnShrink Flag to replace the volume if its size is decreased.
+	ReplaceOnShrink types.Bool `tfsdk:"replace_on_shrink"`
+
 	// Size The storage size of this volume given in GiB.
 	Size types.Int64 `tfsdk:"size"`
 
+	// SourceSnapshotId The id of the snapshot the volume is created from.
+	SourceSnapshotId types.String `tfsdk:"source_snapshot_id"`
+
+	// SourceVolumeId The id of the volume the volume is cloned from.
+	SourceVolumeId types.String `tfsdk:"source_volume_id"`
+
 	// Status The volume status.
 	Status types.String `tfsdk:"status"`
 